@@ -1,20 +1,27 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
 	"github.com/xuri/excelize/v2"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/batch"
 )
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("使用方法: go run main.go <excel文件路径>")
+	suggest := flag.Bool("suggest", false, "推测每个工作表的表头行和仓库地址列")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Println("使用方法: go run main.go [-suggest] <excel文件路径>")
 		fmt.Println("示例: go run main.go \"议题三 待筛选 名单.xlsx\"")
+		fmt.Println("      go run main.go -suggest \"议题三 待筛选 名单.xlsx\"")
 		os.Exit(1)
 	}
 
-	filename := os.Args[1]
+	filename := flag.Arg(0)
 
 	// 打开Excel文件
 	fmt.Printf("📖 正在读取Excel文件: %s\n", filename)
@@ -50,6 +57,11 @@ func main() {
 			continue
 		}
 
+		if *suggest {
+			printSuggestion(sheetName, rows)
+			continue
+		}
+
 		// 显示表头（第一行）
 		if len(rows) > 0 {
 			fmt.Printf("🏷️ 表头: ")
@@ -97,4 +109,21 @@ func main() {
 	}
 
 	fmt.Printf("✅ Excel文件读取完成！\n")
+}
+
+// printSuggestion 输出推测的表头行、仓库地址列以及对应的 batch 命令参数
+func printSuggestion(sheetName string, rows [][]string) {
+	layout, ok := batch.SuggestLayout(rows)
+	if !ok {
+		if layout.RepoColumn == -1 {
+			fmt.Printf("⚠️ 未找到 GitHub/Gitee 仓库地址\n\n")
+		} else {
+			fmt.Printf("⚠️ 仓库地址在第 %s 列，但地址上方没有表头\n\n", layout.ColumnLetter())
+		}
+		return
+	}
+
+	fmt.Printf("🏷️ 表头行: 第 %d 行\n", layout.HeaderRow)
+	fmt.Printf("🔗 仓库地址列: %s 列（%s），%d 个单元格包含仓库地址\n", layout.ColumnLetter(), layout.Header, layout.Repositories)
+	fmt.Printf("💡 建议参数: --sheet %q %s\n\n", sheetName, layout.Flags())
 }
\ No newline at end of file
@@ -2,6 +2,10 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/luoliwoshang/git-event-monitor/internal/models"
 )
@@ -19,12 +23,70 @@ type Client interface {
 	// 返回 true 表示仓库有代码提交，false 表示空仓库
 	HasCommits(ctx context.Context, repo string, token string) (bool, error)
 
+	// GetBranchCommits 获取各分支上截止时间前后最新的提交
+	// branches 为要检查的分支（支持通配符），为空时检查所有分支；最多检查 MaxBranchCommits 个分支，每个分支两次请求
+	// 用于没有推送事件时按提交时间判断，提交时间可以被伪造，结果只能作为低可信度的依据
+	GetBranchCommits(ctx context.Context, repo string, token string, deadline time.Time, branches []string) ([]*models.BranchCommits, error)
+
+	// GetRepository 获取仓库信息（可见性、fork 上游、创建时间、是否归档等）
+	GetRepository(ctx context.Context, repo string, token string) (*models.Repository, error)
+
 	// GetPlatform 获取平台类型
 	GetPlatform() models.Platform
 }
 
+// MaxBranchCommits GetBranchCommits 最多检查的分支数
+const MaxBranchCommits = 10
+
 // RequestOptions API 请求选项
 type RequestOptions struct {
 	Token   string
 	PerPage int
-}
\ No newline at end of file
+}
+
+// StatusError API 返回非 200 状态码时的错误
+type StatusError struct {
+	StatusCode int
+}
+
+// Error 实现 error 接口
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API request failed with status %d", e.StatusCode)
+}
+
+// Temporary 判断该状态码是否可能在重试后恢复（服务端错误或限流）
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode >= 500 ||
+		e.StatusCode == http.StatusTooManyRequests ||
+		e.StatusCode == http.StatusForbidden
+}
+
+// 请求失败的分类（见 ErrorCategory），写入结果的错误类型
+const (
+	ErrorNotFound    = "not_found"
+	ErrorForbidden   = "forbidden"
+	ErrorRateLimited = "rate_limited"
+	ErrorServer      = "server_error"
+	ErrorHTTP        = "http_error"
+	ErrorNetwork     = "network"
+)
+
+// ErrorCategory 根据请求错误判断失败分类：有状态码时按状态码分类，否则为网络错误
+func ErrorCategory(err error) string {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return ErrorNetwork
+	}
+	switch code := statusErr.StatusCode; {
+	case code == http.StatusNotFound:
+		return ErrorNotFound
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrorForbidden
+	case code == http.StatusTooManyRequests:
+		return ErrorRateLimited
+	case code >= 500:
+		return ErrorServer
+	default:
+		return ErrorHTTP
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
 	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
 )
 
 // Client Gitee API 客户端
@@ -27,6 +31,14 @@ func NewClient() *Client {
 	}
 }
 
+// NewClientWithHTTPClient 使用自定义 HTTP 客户端创建 Gitee 客户端
+// 用于共享连接池或配额限制等场景
+func NewClientWithHTTPClient(httpClient *http.Client) *Client {
+	c := NewClient()
+	c.httpClient = httpClient
+	return c
+}
+
 // GetPlatform 获取平台类型
 func (c *Client) GetPlatform() models.Platform {
 	return models.PlatformGitee
@@ -61,7 +73,7 @@ func (c *Client) GetEvents(ctx context.Context, repo string, token string) ([]*m
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, &api.StatusError{StatusCode: resp.StatusCode}
 	}
 
 	var giteeEvents []models.GiteeEvent
@@ -78,6 +90,172 @@ func (c *Client) GetEvents(ctx context.Context, repo string, token string) ([]*m
 	return events, nil
 }
 
+// giteeRepository Gitee 仓库信息中用到的字段
+type giteeRepository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+	Private  bool   `json:"private"`
+	Internal bool   `json:"internal"`
+	Fork     bool   `json:"fork"`
+	Parent   *struct {
+		FullName string `json:"full_name"`
+	} `json:"parent"`
+	CreatedAt     string `json:"created_at"`
+	PushedAt      string `json:"pushed_at"`
+	DefaultBranch string `json:"default_branch"`
+	// Status 仓库状态（开始、暂停、关闭），Gitee 没有单独的归档字段
+	Status  string `json:"status"`
+	License string `json:"license"`
+}
+
+// GetRepository 获取仓库信息
+func (c *Client) GetRepository(ctx context.Context, repo string, token string) (*models.Repository, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repository format, expected 'owner/repo'")
+	}
+
+	var info giteeRepository
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/repos/%s/%s", c.baseURL, parts[0], parts[1]), token, url.Values{}, &info); err != nil {
+		return nil, err
+	}
+	return info.toRepository(), nil
+}
+
+// toRepository 转换为统一的仓库信息模型
+// Gitee 不提供仓库大小；状态为"关闭"的仓库视为已归档
+func (r *giteeRepository) toRepository() *models.Repository {
+	repo := &models.Repository{
+		FullName:      r.FullName,
+		HTMLURL:       r.HTMLURL,
+		Visibility:    models.VisibilityPublic,
+		Fork:          r.Fork,
+		CreatedAt:     r.CreatedAt,
+		PushedAt:      r.PushedAt,
+		DefaultBranch: r.DefaultBranch,
+		Archived:      r.Status == "关闭",
+		License:       r.License,
+	}
+	switch {
+	case r.Private:
+		repo.Visibility = models.VisibilityPrivate
+	case r.Internal:
+		repo.Visibility = models.VisibilityInternal
+	}
+	if r.Parent != nil {
+		repo.Parent = r.Parent.FullName
+	}
+	return repo
+}
+
+// giteeBranch 分支列表中用到的字段
+type giteeBranch struct {
+	Name string `json:"name"`
+}
+
+// giteeCommit 提交列表中用到的字段
+type giteeCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name string `json:"name"`
+		} `json:"author"`
+		Committer struct {
+			Date string `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+// GetBranchCommits 获取各分支上截止时间前后最新的提交
+// 从前 100 个分支中按 patterns 选出最多 api.MaxBranchCommits 个分支（见 monitor.SelectBranches），
+// 每个分支分别用 until 和 since 查询截止时间前后最新的一个提交
+func (c *Client) GetBranchCommits(ctx context.Context, repo string, token string, deadline time.Time, patterns []string) ([]*models.BranchCommits, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repository format, expected 'owner/repo'")
+	}
+
+	query := url.Values{}
+	query.Set("per_page", "100")
+	var branches []giteeBranch
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/repos/%s/%s/branches", c.baseURL, parts[0], parts[1]), token, query, &branches); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(branches))
+	for i, branch := range branches {
+		names[i] = branch.Name
+	}
+
+	var result []*models.BranchCommits
+	for _, name := range monitor.SelectBranches(names, patterns, api.MaxBranchCommits) {
+		before, err := c.latestCommit(ctx, parts, token, name, "until", deadline)
+		if err != nil {
+			return nil, err
+		}
+		// since 包含该时刻本身，截止时间整点的提交算作准时
+		after, err := c.latestCommit(ctx, parts, token, name, "since", deadline.Add(time.Second))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &models.BranchCommits{Branch: name, Before: before, After: after})
+	}
+	return result, nil
+}
+
+// latestCommit 查询分支上 until 之前或 since 之后最新的一个提交，没有时返回 nil
+func (c *Client) latestCommit(ctx context.Context, parts []string, token, branch, param string, t time.Time) (*models.Commit, error) {
+	query := url.Values{}
+	query.Set("sha", branch)
+	query.Set(param, t.UTC().Format(time.RFC3339))
+	query.Set("per_page", "1")
+
+	var commits []giteeCommit
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/repos/%s/%s/commits", c.baseURL, parts[0], parts[1]), token, query, &commits); err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	commit := commits[0]
+	return &models.Commit{
+		SHA:         commit.SHA,
+		Author:      commit.Commit.Author.Name,
+		Message:     commit.Commit.Message,
+		CommittedAt: commit.Commit.Committer.Date,
+		URL:         commit.HTMLURL,
+	}, nil
+}
+
+// getJSON 发送 GET 请求并解析 JSON 响应，token 通过 access_token 参数传递
+func (c *Client) getJSON(ctx context.Context, endpoint, token string, query url.Values, v interface{}) error {
+	if token != "" {
+		query.Set("access_token", token)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &api.StatusError{StatusCode: resp.StatusCode}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
 // AnalyzeCodeEvents 分析代码提交事件
 func (c *Client) AnalyzeCodeEvents(ctx context.Context, req *models.AnalysisRequest) (*models.AnalysisResult, error) {
 	events, err := c.GetEvents(ctx, req.Repository, req.Token)
@@ -89,53 +267,66 @@ func (c *Client) AnalyzeCodeEvents(ctx context.Context, req *models.AnalysisRequ
 		}, nil
 	}
 
-	// 过滤代码提交事件
+	// 过滤代码提交事件，指定了分支时只保留推送到这些分支的事件
 	var codeEvents []*models.UnifiedEvent
 	for _, event := range events {
 		if isCodeSubmissionEvent(event) {
 			codeEvents = append(codeEvents, event)
 		}
 	}
+	codeEvents = monitor.FilterBranchEvents(codeEvents, req.Branches)
 
 	result := &models.AnalysisResult{
 		Found:         len(codeEvents) > 0,
 		EventsChecked: len(events),
+		Deadline:      req.Deadline,
 	}
 
 	if !result.Found {
-		result.Error = fmt.Sprintf("在最近的 %d 个仓库事件中未找到代码提交事件", len(events))
+		result.ErrorCode = models.ErrorNoCodeEvents
+		result.Error = i18n.T(i18n.MsgNoCodeEvents, len(events))
 		return result, nil
 	}
 
 	// 获取最近的代码事件
 	lastEvent := codeEvents[0]
 	result.LastCodeEvent = lastEvent
-	result.EventDescription = fmt.Sprintf("最近的 %s (%s)", lastEvent.Type, lastEvent.CreatedAt)
+	result.EventDescription = i18n.T(i18n.MsgLatestEvent, lastEvent.Type, lastEvent.CreatedAt)
+	result.EarliestCodeEvent = codeEvents[len(codeEvents)-1]
+
+	// 如果提供了比赛开始时间，统计开始前的推送
+	if req.Start != "" {
+		start, err := time.Parse(time.RFC3339, req.Start)
+		if err != nil {
+			result.ErrorCode = models.ErrorInvalidStart
+			result.Error = i18n.T(i18n.MsgInvalidStart, err.Error())
+			return result, nil
+		}
+		result.PushesBeforeStart, result.CommitsBeforeStart = monitor.StartStats(codeEvents, start)
+	}
 
 	// 如果提供了截止时间，检查合规性
 	if req.Deadline != "" {
 		deadline, err := time.Parse(time.RFC3339, req.Deadline)
 		if err != nil {
-			result.Error = fmt.Sprintf("截止时间格式错误: %s", err.Error())
+			result.ErrorCode = models.ErrorInvalidDeadline
+			result.Error = i18n.T(i18n.MsgInvalidDeadline, err.Error())
 			return result, nil
 		}
 
 		eventTime, err := time.Parse(time.RFC3339, lastEvent.CreatedAt)
 		if err != nil {
-			result.Error = fmt.Sprintf("事件时间格式错误: %s", err.Error())
+			result.ErrorCode = models.ErrorInvalidEventTime
+			result.Error = i18n.T(i18n.MsgInvalidEventTime, err.Error())
 			return result, nil
 		}
 
 		isBeforeDeadline := eventTime.Before(deadline) || eventTime.Equal(deadline)
 		result.SubmittedBefore = &isBeforeDeadline
+		result.Confidence = models.ConfidenceHigh
+		result.LastBeforeDeadline, result.LatePushes = monitor.DeadlineStats(codeEvents, deadline)
 
-		// 计算时间差
-		timeDiff := deadline.Sub(eventTime)
-		if timeDiff > 0 {
-			result.TimeDifference = fmt.Sprintf("截止时间前 %s", formatDuration(timeDiff))
-		} else {
-			result.TimeDifference = fmt.Sprintf("超过截止时间 %s", formatDuration(-timeDiff))
-		}
+		monitor.SetTimeDifference(result, eventTime, deadline)
 	}
 
 	return result, nil
@@ -146,28 +337,6 @@ func isCodeSubmissionEvent(event *models.UnifiedEvent) bool {
 	return event.Type == "PushEvent"
 }
 
-// formatDuration 格式化持续时间
-func formatDuration(d time.Duration) string {
-	if d < time.Hour {
-		return fmt.Sprintf("%d分钟", int(d.Minutes()))
-	}
-	if d < 24*time.Hour {
-		hours := int(d.Hours())
-		minutes := int(d.Minutes()) % 60
-		if minutes > 0 {
-			return fmt.Sprintf("%d小时%d分钟", hours, minutes)
-		}
-		return fmt.Sprintf("%d小时", hours)
-	}
-
-	days := int(d.Hours()) / 24
-	hours := int(d.Hours()) % 24
-	if hours > 0 {
-		return fmt.Sprintf("%d天%d小时", days, hours)
-	}
-	return fmt.Sprintf("%d天", days)
-}
-
 // HasCommits 检查Gitee仓库是否有提交记录
 // 通过调用Gitee Commits API来判断仓库是否为空
 // 返回值：
@@ -181,7 +350,7 @@ func (c *Client) HasCommits(ctx context.Context, repo string, token string) (boo
 	// 创建HTTP请求
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return false, fmt.Errorf("创建请求失败: %w", err)
+		return false, fmt.Errorf("create request: %w", err)
 	}
 
 	// 如果提供了token，添加认证头
@@ -192,7 +361,7 @@ func (c *Client) HasCommits(ctx context.Context, repo string, token string) (boo
 	// 发送HTTP请求
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return false, fmt.Errorf("请求失败: %w", err)
+		return false, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -206,7 +375,7 @@ func (c *Client) HasCommits(ctx context.Context, repo string, token string) (boo
 		// 在这种情况下，我们认为是没有提交记录
 		return false, nil
 	default:
-		// 其他状态码表示API调用出现异常
-		return false, fmt.Errorf("Gitee API返回异常状态码: %d", resp.StatusCode)
+		// 其他状态码表示API调用出现异常，与其他请求一样返回 StatusError，便于按状态码分类和重试
+		return false, &api.StatusError{StatusCode: resp.StatusCode}
 	}
 }
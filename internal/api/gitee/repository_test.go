@@ -0,0 +1,47 @@
+package gitee
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestGiteeClient_GetRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/team/fork" || r.URL.Query().Get("access_token") != "secret" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{
+			"full_name": "team/fork", "private": true, "internal": false,
+			"fork": true, "parent": {"full_name": "other/upstream"},
+			"created_at": "2025-08-01T08:00:00+08:00", "pushed_at": "2025-09-30T18:00:00+08:00",
+			"default_branch": "master", "status": "关闭", "license": "Apache-2.0"
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithHTTPClient(server.Client())
+	client.baseURL = server.URL
+
+	repo, err := client.GetRepository(context.Background(), "team/fork", "secret")
+	if err != nil {
+		t.Fatalf("GetRepository failed: %v", err)
+	}
+	want := models.Repository{
+		FullName: "team/fork", Visibility: models.VisibilityPrivate,
+		Fork: true, Parent: "other/upstream",
+		CreatedAt: "2025-08-01T08:00:00+08:00", PushedAt: "2025-09-30T18:00:00+08:00",
+		DefaultBranch: "master", Archived: true, License: "Apache-2.0",
+	}
+	if *repo != want {
+		t.Errorf("GetRepository() = %+v, want %+v", *repo, want)
+	}
+
+	if _, err := client.GetRepository(context.Background(), "team/missing", ""); err == nil {
+		t.Error("Expected error for missing repository")
+	}
+}
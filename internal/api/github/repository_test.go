@@ -0,0 +1,96 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestGitHubClient_GetRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/team/fork" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{
+			"full_name": "team/fork", "private": false, "visibility": "public",
+			"fork": true, "parent": {"full_name": "other/upstream"},
+			"created_at": "2025-08-01T00:00:00Z", "pushed_at": "2025-09-30T10:00:00Z",
+			"default_branch": "main", "archived": true, "size": 128,
+			"license": {"spdx_id": "MIT"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithHTTPClient(server.Client())
+	client.baseURL = server.URL
+
+	repo, err := client.GetRepository(context.Background(), "team/fork", "")
+	if err != nil {
+		t.Fatalf("GetRepository failed: %v", err)
+	}
+	want := models.Repository{
+		FullName: "team/fork", Visibility: models.VisibilityPublic,
+		Fork: true, Parent: "other/upstream",
+		CreatedAt: "2025-08-01T00:00:00Z", PushedAt: "2025-09-30T10:00:00Z",
+		DefaultBranch: "main", Archived: true, Size: 128, License: "MIT",
+	}
+	if *repo != want {
+		t.Errorf("GetRepository() = %+v, want %+v", *repo, want)
+	}
+
+	if _, err := client.GetRepository(context.Background(), "team/missing", ""); err == nil {
+		t.Error("Expected error for missing repository")
+	}
+}
+
+func TestGitHubClient_GetBranchCommits(t *testing.T) {
+	deadline := time.Date(2025, 9, 30, 16, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/repos/team/repo/branches":
+			w.Write([]byte(`[{"name":"main"},{"name":"dev"}]`))
+		case r.URL.Path != "/repos/team/repo/commits" || q.Get("per_page") != "1":
+			http.NotFound(w, r)
+		case q.Get("until") == "2025-09-30T16:00:00Z":
+			w.Write([]byte(`[{"sha":"` + q.Get("sha") + `-before","commit":{"message":"m","author":{"name":"a"},"committer":{"date":"2025-09-30T10:00:00Z"}}}]`))
+		case q.Get("since") == "2025-09-30T16:00:01Z" && q.Get("sha") == "dev":
+			w.Write([]byte(`[{"sha":"dev-after","html_url":"https://github.com/team/repo/commit/dev-after","commit":{"committer":{"date":"2025-10-01T10:00:00Z"}}}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithHTTPClient(server.Client())
+	client.baseURL = server.URL
+
+	branches, err := client.GetBranchCommits(context.Background(), "team/repo", "", deadline, nil)
+	if err != nil {
+		t.Fatalf("GetBranchCommits failed: %v", err)
+	}
+	if len(branches) != 2 {
+		t.Fatalf("Expected 2 branches, got %d", len(branches))
+	}
+	main, dev := branches[0], branches[1]
+	if main.Branch != "main" || main.Before == nil || main.Before.SHA != "main-before" || main.After != nil {
+		t.Errorf("Unexpected main branch: %+v", main)
+	}
+	if main.Before.Author != "a" || main.Before.CommittedAt != "2025-09-30T10:00:00Z" {
+		t.Errorf("Unexpected commit: %+v", main.Before)
+	}
+	if dev.After == nil || dev.After.SHA != "dev-after" || dev.After.URL != "https://github.com/team/repo/commit/dev-after" {
+		t.Errorf("Unexpected dev branch: %+v", dev)
+	}
+
+	// 指定分支时只查询这些分支
+	branches, err = client.GetBranchCommits(context.Background(), "team/repo", "", deadline, []string{"dev"})
+	if err != nil || len(branches) != 1 || branches[0].Branch != "dev" {
+		t.Errorf("Expected only the dev branch, got %v (%v)", branches, err)
+	}
+}
@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultReserve 保留的请求配额，剩余配额低于该值时暂停发送请求
+const defaultReserve = 5
+
+// Limiter 共享的 API 配额状态
+// 多个并发请求共用同一个 Limiter 时，会把正在进行的请求计入已用配额，
+// 保证并发不会把配额耗尽；配额不足时等待到平台给出的重置时间
+type Limiter struct {
+	mu        sync.Mutex
+	reserve   int
+	known     bool
+	remaining int
+	reset     time.Time
+	pauseTill time.Time
+	inFlight  int
+	now       func() time.Time
+}
+
+// New 创建配额限制器
+func New() *Limiter {
+	return &Limiter{
+		reserve: defaultReserve,
+		now:     time.Now,
+	}
+}
+
+// Acquire 获取一次请求配额，必要时等待配额重置
+// 请求完成后必须调用 Release
+func (l *Limiter) Acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		wait := l.waitDuration()
+		if wait <= 0 {
+			l.inFlight++
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// waitDuration 计算当前需要等待的时间，调用方需持有锁
+func (l *Limiter) waitDuration() time.Duration {
+	now := l.now()
+
+	if now.Before(l.pauseTill) {
+		return l.pauseTill.Sub(now)
+	}
+
+	if !l.known {
+		return 0
+	}
+	if !now.Before(l.reset) {
+		// 已过重置时间，配额状态未知，等待下一次响应更新
+		l.known = false
+		return 0
+	}
+	if l.remaining-l.inFlight > l.reserve {
+		return 0
+	}
+	return l.reset.Sub(now) + time.Second
+}
+
+// Release 归还请求配额，并根据响应头更新配额状态
+// resp 可以为 nil（请求失败时）
+func (l *Limiter) Release(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	if resp == nil {
+		return
+	}
+
+	now := l.now()
+	header := resp.Header
+
+	if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			l.known = true
+			l.remaining = remaining
+			l.reset = time.Unix(reset, 0)
+		}
+	}
+
+	// 二级限流或 429：按 Retry-After 暂停所有请求
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusForbidden {
+		if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+			pause := now.Add(time.Duration(seconds) * time.Second)
+			if pause.After(l.pauseTill) {
+				l.pauseTill = pause
+			}
+		}
+	}
+}
+
+// Transport 在请求前后自动获取和归还配额的 http.RoundTripper
+type Transport struct {
+	Base    http.RoundTripper
+	Limiter *Limiter
+	// Timeout 获取配额后单次请求的超时时间，为0时不限制
+	// 等待配额的时间不计入超时，避免配额耗尽时请求被误判为失败
+	Timeout time.Duration
+}
+
+// NewTransport 创建使用指定限制器的 Transport，base 为空时使用 http.DefaultTransport
+func NewTransport(base http.RoundTripper, limiter *Limiter, timeout time.Duration) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Base: base, Limiter: limiter, Timeout: timeout}
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.Acquire(req.Context()); err != nil {
+		return nil, err
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if t.Timeout > 0 {
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(req.Context(), t.Timeout)
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		cancel()
+		t.Limiter.Release(nil)
+		return nil, err
+	}
+	t.Limiter.Release(resp)
+
+	// 响应体读取完毕后再取消超时上下文
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose 关闭响应体时释放超时上下文
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close 关闭响应体并释放上下文
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
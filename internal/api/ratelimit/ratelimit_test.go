@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func responseWithQuota(remaining int, reset time.Time) *http.Response {
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return &http.Response{StatusCode: http.StatusOK, Header: header}
+}
+
+func TestLimiter_AllowsWhenQuotaUnknown(t *testing.T) {
+	l := New()
+	for i := 0; i < 20; i++ {
+		if err := l.Acquire(context.Background()); err != nil {
+			t.Fatalf("Acquire %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestLimiter_CountsInFlightRequests(t *testing.T) {
+	now := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	l := New()
+	l.now = func() time.Time { return now }
+
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	l.Release(responseWithQuota(defaultReserve+2, now.Add(time.Hour)))
+
+	// 还剩 reserve+2 个配额，两个并发请求可以发出
+	for i := 0; i < 2; i++ {
+		if err := l.Acquire(context.Background()); err != nil {
+			t.Fatalf("Acquire %d failed: %v", i, err)
+		}
+	}
+
+	// 第三个请求会把配额压到保留值以下，必须等待
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Acquire(ctx); err == nil {
+		t.Fatal("Expected Acquire to block when quota is exhausted")
+	}
+}
+
+func TestLimiter_ResumesAfterReset(t *testing.T) {
+	now := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	l := New()
+	l.now = func() time.Time { return now }
+
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	l.Release(responseWithQuota(0, now.Add(time.Minute)))
+
+	// 重置时间过后配额状态变为未知，允许继续请求
+	now = now.Add(2 * time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Acquire(ctx); err != nil {
+		t.Fatalf("Expected Acquire to succeed after reset, got %v", err)
+	}
+}
+
+func TestLimiter_RetryAfter(t *testing.T) {
+	now := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	l := New()
+	l.now = func() time.Time { return now }
+
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	header := http.Header{}
+	header.Set("Retry-After", "60")
+	l.Release(&http.Response{StatusCode: http.StatusTooManyRequests, Header: header})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Acquire(ctx); err == nil {
+		t.Fatal("Expected Acquire to wait for Retry-After")
+	}
+}
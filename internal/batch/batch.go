@@ -0,0 +1,576 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/api/ratelimit"
+	"github.com/luoliwoshang/git-event-monitor/internal/config"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+	"github.com/luoliwoshang/git-event-monitor/internal/platform"
+	"github.com/luoliwoshang/git-event-monitor/internal/webhook"
+)
+
+// 结果列取值，与语言无关；写入表格时由 StatusText 转换为当前语言的文字（见 output.ResultStatus）
+const (
+	StatusAccessible             = output.StatusAccessible
+	StatusInaccessible           = output.StatusInaccessible
+	StatusNoDeadline             = output.StatusNoDeadline
+	StatusAnalysisFailed         = output.StatusAnalysisFailed
+	StatusInitialCommit          = output.StatusInitialCommit
+	StatusEmptyRepo              = output.StatusEmptyRepo
+	StatusUndetermined           = output.StatusUndetermined
+	StatusOnTime                 = output.StatusOnTime
+	StatusLate                   = output.StatusLate
+	StatusOnTimeMediumConfidence = output.StatusOnTimeMediumConfidence
+	StatusLateMediumConfidence   = output.StatusLateMediumConfidence
+	StatusOnTimeLowConfidence    = output.StatusOnTimeLowConfidence
+	StatusLateLowConfidence      = output.StatusLateLowConfidence
+)
+
+// StatusText 按当前语言返回状态值的文字，空值返回空字符串
+func StatusText(status string) string {
+	return output.StatusText(status)
+}
+
+// 错误分类，写入结果的 error_category 字段和"错误类型"列
+const (
+	ErrorNotFound        = api.ErrorNotFound
+	ErrorForbidden       = api.ErrorForbidden
+	ErrorRateLimited     = api.ErrorRateLimited
+	ErrorServer          = api.ErrorServer
+	ErrorHTTP            = api.ErrorHTTP
+	ErrorNetwork         = api.ErrorNetwork
+	ErrorInternal        = "internal"
+	ErrorInvalidTime     = models.ErrorInvalidTime
+	ErrorNoPushEvents    = models.ErrorNoPushEvents
+	ErrorEmptyRepository = "empty_repository"
+)
+
+// defaultTimeout 单次 API 请求的默认超时时间
+const defaultTimeout = 10 * time.Second
+
+// Options 批量处理选项
+type Options struct {
+	// Deadline 截止时间（RFC3339），为空时只检查可访问性；截止时间列也接受 "2006-01-02 15:04" 等写法
+	Deadline string
+	// StartRow 起始行号（1-based，表头为第1行，因此至少为2）
+	StartRow int
+	// EndRow 结束行号（1-based，包含），为0时处理到最后一行
+	EndRow int
+	// TokenFor 返回指定平台使用的 API Token
+	TokenFor func(models.Platform) string
+	// NewClient 创建平台客户端，为空时为每个平台创建一个带共享配额限制的客户端
+	NewClient func(models.Platform) (api.Client, error)
+	// Formatter 非空时，每行中各仓库的分析结果会额外通过该格式化器逐个输出到 FormatterOut（见 output.NewStream）
+	Formatter output.Formatter
+	// FormatterOut Formatter 的输出位置，为空时为标准输出
+	FormatterOut io.Writer
+	// SummaryFormat 非空时，处理完成后按该格式（markdown、html 或 template）生成汇总文件（见 SummaryPath）
+	SummaryFormat string
+	// SummaryTemplate SummaryFormat 为 template 时使用的模板文件或内置模板名称
+	SummaryTemplate string
+	// Log 处理日志输出，为空时输出到标准输出
+	Log io.Writer
+	// Timeout 默认客户端单次 API 请求的超时时间（不含等待配额的时间），为0时使用默认值
+	Timeout time.Duration
+	// Concurrency 同时处理的行数，小于1时按1处理
+	Concurrency int
+	// CheckpointPath 断点记录文件路径，为空时不记录
+	CheckpointPath string
+	// Resume 为 true 时跳过断点记录中已得到确定结果的行，临时性失败的行会重新处理
+	Resume bool
+	// ReportPath NDJSON 报告文件路径（见 ReportRow 和 ReportSummary），为空时不生成
+	ReportPath string
+	// Columns 表格列映射，未指定仓库地址列时使用 DefaultColumnMapping
+	Columns ColumnMapping
+	// Sheet 要处理的 Excel 工作表（名称或从1开始的序号），为空时处理第一个工作表
+	Sheet string
+	// SheetPattern 工作表名称正则，非空时处理所有匹配的工作表（与 Sheet 互斥）
+	SheetPattern string
+	// HeaderRow 表头所在行（1-based），为0时为第1行；表头之前的行（如标题横幅）保持不变
+	HeaderRow int
+	// Location 结果列中时间的显示时区，也用于解析截止时间列中不带时区的时间，为空时使用本地时区
+	Location *time.Location
+	// Start 比赛开始时间，非零值时标记在开始前创建的仓库
+	Start time.Time
+	// CommitsBeforeStart 开始前推送的提交数达到该值时标记，为 0 时使用赛道的设置或 monitor.DefaultCommitsBeforeStart
+	CommitsBeforeStart int
+	// SkipRepoInfo 为 true 时不查询仓库信息（每个仓库少一次 API 调用），不标记私有、fork、归档和开始前创建的仓库，也不检测 fork
+	SkipRepoInfo bool
+	// Policy 宽限时间和扣分档位，设置了扣分档位时添加"扣分"列
+	Policy models.LatenessPolicy
+	// GraceSet、PenaltySet 命令行是否指定了宽限时间和扣分档位，指定的部分覆盖赛道的设置（见 config.Track.MergePolicy）
+	GraceSet   bool
+	PenaltySet bool
+	// Contest 比赛配置，非空时每行按参赛者所在赛道的截止时间、开始时间、宽限时间、扣分和分支规则检查
+	Contest *config.Contest
+	// Webhooks serve-webhooks 收到的 Webhook 记录（见 webhook.LoadRecords），作为高可信度的依据加入各仓库的结论
+	Webhooks []*webhook.Record
+	// DryRun 为 true 时只校验表格（见 ValidateSheets），不调用任何 API
+	DryRun bool
+	// WriteValidation 校验时把每行的问题写入校验结果文件（见 ValidationPath）的"校验结果"列
+	WriteValidation bool
+}
+
+// RepoResult 单个仓库的检查结果
+type RepoResult struct {
+	RepoURL    string                 `json:"repo_url"`
+	Platform   models.Platform        `json:"platform"`
+	Repository string                 `json:"repository"`
+	Access     string                 `json:"access,omitempty"`
+	Submission string                 `json:"submission,omitempty"`
+	Result     *models.AnalysisResult `json:"result,omitempty"`
+	HasCommits *bool                  `json:"has_commits,omitempty"`
+	// Error 检查失败的原因，ErrorCategory 为对应的错误分类
+	Error         string `json:"error,omitempty"`
+	ErrorCategory string `json:"error_category,omitempty"`
+	Transient     bool   `json:"transient,omitempty"`
+	// Info 仓库信息，Flags 为需要人工复核的标记（见 monitor.RepositoryFlags）
+	Info  *models.Repository `json:"repository_info,omitempty"`
+	Flags []string           `json:"flags,omitempty"`
+	// Verdict 结构化的结论、可信度和依据
+	Verdict *models.Verdict `json:"verdict,omitempty"`
+}
+
+// RowResult 单行处理结果
+// 一个单元格中可以填写多个仓库，Access 和 Submission 为所有仓库的汇总结果
+type RowResult struct {
+	Sheet      string        `json:"sheet,omitempty"`
+	Row        int           `json:"row"`
+	Name       string        `json:"name,omitempty"`
+	Team       string        `json:"team,omitempty"`
+	RepoURL    string        `json:"repo_url"`
+	Deadline   string        `json:"deadline,omitempty"`
+	Track      string        `json:"track,omitempty"`
+	Skipped    bool          `json:"skipped,omitempty"`
+	Access     string        `json:"access,omitempty"`
+	Submission string        `json:"submission,omitempty"`
+	Repos      []*RepoResult `json:"repos,omitempty"`
+	// Lateness 各仓库中扣分最多（相同时迟交最久）的迟交档位
+	Lateness *models.Lateness `json:"lateness,omitempty"`
+	// Transient 表示结果来自临时性失败（网络错误、限流等），断点续跑时会重新处理
+	Transient bool `json:"transient,omitempty"`
+	// Restored 表示结果来自断点记录
+	Restored bool `json:"-"`
+}
+
+// Summary 批量处理汇总
+type Summary struct {
+	Rows       []*RowResult `json:"rows"`
+	Processed  int          `json:"processed"`
+	Skipped    int          `json:"skipped"`
+	OutputFile string       `json:"output_file,omitempty"`
+	// DetailsFile 仓库明细的保存位置（Excel 为结果文件中的工作表，CSV 为单独的文件）
+	DetailsFile string `json:"details_file,omitempty"`
+	// ReportFile NDJSON 报告文件
+	ReportFile string `json:"report_file,omitempty"`
+	// SummaryFile markdown 或 html 汇总文件
+	SummaryFile string `json:"summary_file,omitempty"`
+	// Shared 多行重复填写或互为 fork 的仓库
+	Shared []SharedRepository `json:"shared,omitempty"`
+	// Validation 校验报告，只在 DryRun 时生成
+	Validation *ValidationReport `json:"validation,omitempty"`
+}
+
+// Processor 批量处理器
+type Processor struct {
+	opts      Options
+	log       io.Writer
+	clientsMu sync.Mutex
+	clients   map[models.Platform]api.Client
+}
+
+// NewProcessor 创建批量处理器
+func NewProcessor(opts Options) *Processor {
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.NewClient == nil {
+		// 客户端按平台缓存，所有 worker 共用同一个配额限制器
+		timeout := opts.Timeout
+		opts.NewClient = func(p models.Platform) (api.Client, error) {
+			return platform.NewRateLimitedClient(p, ratelimit.New(), timeout)
+		}
+	}
+	if opts.TokenFor == nil {
+		opts.TokenFor = func(models.Platform) string { return "" }
+	}
+	if opts.Columns.Repository.IsZero() {
+		opts.Columns = DefaultColumnMapping()
+	}
+	// 可选结果列名称在这里规范化（展开 "all"），未知名称留到处理时报错
+	if extra, err := ParseExtraColumns(opts.Columns.Extra); err == nil {
+		opts.Columns.Extra = extra
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.HeaderRow < 1 {
+		opts.HeaderRow = 1
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+
+	log := opts.Log
+	if log == nil {
+		log = os.Stdout
+	}
+
+	return &Processor{
+		opts:    opts,
+		log:     log,
+		clients: make(map[models.Platform]api.Client),
+	}
+}
+
+// Run 读取文件，处理指定范围的行，并把结果写入新文件
+func Run(ctx context.Context, filename string, opts Options) (*Summary, error) {
+	p := NewProcessor(opts)
+
+	p.logf("🚀 Starting batch processing...\n")
+	p.logf("File: %s\n", filename)
+	if opts.Deadline != "" {
+		p.logf("Deadline: %s\n", monitor.FormatDualTime(opts.Deadline, p.opts.Location))
+	}
+	for _, pl := range platform.Supported {
+		if token := p.opts.TokenFor(pl); token != "" {
+			p.logf("%s Token: %s\n", pl, maskToken(token))
+		}
+	}
+	p.logf("\n")
+
+	sheets, err := ReadSheets(filename, opts.Sheet, opts.SheetPattern)
+	if err != nil {
+		return nil, fmt.Errorf("文件读取失败: %w", err)
+	}
+
+	if opts.DryRun {
+		return p.dryRun(filename, sheets)
+	}
+
+	summary, err := p.ProcessSheets(ctx, sheets)
+	if err != nil {
+		return nil, err
+	}
+
+	outputFile, err := WriteFile(filename, sheets)
+	if err != nil {
+		return nil, fmt.Errorf("文件写入失败: %w", err)
+	}
+	summary.OutputFile = outputFile
+
+	p.logf("💾 Results saved to: %s\n", outputFile)
+
+	// 有单元格填写了多个仓库时，另外保存每个仓库的明细
+	if hasMultiRepoRows(summary.Rows) {
+		detailsFile, err := WriteDetails(filename, outputFile, DetailRecords(summary.Rows, p.opts.Columns.Extra, p.opts.Location))
+		if err != nil {
+			return nil, fmt.Errorf("仓库明细写入失败: %w", err)
+		}
+		summary.DetailsFile = detailsFile
+		p.logf("💾 Repository details saved to: %s\n", detailsFile)
+	}
+
+	if summary.ReportFile != "" {
+		p.logf("💾 Report saved to: %s\n", summary.ReportFile)
+	}
+
+	if opts.SummaryFormat != "" {
+		summaryFile := SummaryPath(filename, opts.SummaryFormat)
+		if err := WriteSummary(summaryFile, opts.SummaryFormat, opts.SummaryTemplate, summary.Rows, p.opts.Location); err != nil {
+			return nil, fmt.Errorf("汇总写入失败: %w", err)
+		}
+		summary.SummaryFile = summaryFile
+		p.logf("💾 Summary saved to: %s\n", summaryFile)
+	}
+
+	p.logf("✅ Done! Results saved\n")
+	return summary, nil
+}
+
+// dryRun 只校验表格，WriteValidation 为 true 时把校验结果写入单独的校验结果文件（见 ValidationPath）
+func (p *Processor) dryRun(filename string, sheets []*Sheet) (*Summary, error) {
+	p.logf("🔎 Dry run: validating without API calls\n\n")
+
+	report, err := p.ValidateSheets(sheets)
+	if err != nil {
+		return nil, err
+	}
+	summary := &Summary{Validation: report}
+
+	if p.opts.WriteValidation {
+		outputFile, err := WriteValidationFile(filename, sheets)
+		if err != nil {
+			return nil, fmt.Errorf("文件写入失败: %w", err)
+		}
+		summary.OutputFile = outputFile
+		p.logf("💾 Validation results saved to: %s\n", outputFile)
+	}
+	return summary, nil
+}
+
+// Process 处理表格数据（第 HeaderRow 行为表头），结果直接写回 records
+// 如果结果列不存在，会追加到表头和每一行数据的末尾
+func (p *Processor) Process(ctx context.Context, records [][]string) (*Summary, error) {
+	return p.ProcessSheets(ctx, []*Sheet{{Records: records}})
+}
+
+// ProcessSheets 依次处理多个工作表，StartRow 和 EndRow 对每个工作表生效
+func (p *Processor) ProcessSheets(ctx context.Context, sheets []*Sheet) (summary *Summary, err error) {
+	var cp *checkpoint
+	var completed map[checkpointKey]*RowResult
+	if p.opts.CheckpointPath != "" {
+		cp, completed, err = openCheckpoint(p.opts.CheckpointPath, p.opts.Resume, p.opts.fingerprint())
+		if err != nil {
+			return nil, err
+		}
+		defer cp.Close()
+		if p.opts.Resume {
+			p.logf("♻️  Resuming from %s (%d rows already completed)\n\n", p.opts.CheckpointPath, len(completed))
+		}
+	}
+
+	summary = &Summary{}
+	var rep *report
+	if p.opts.ReportPath != "" {
+		if rep, err = openReport(p.opts.ReportPath); err != nil {
+			return nil, err
+		}
+		// 汇总记录在所有行处理完后写入，写入失败时整个报告不完整
+		defer func() {
+			if closeErr := rep.Close(); closeErr != nil && err == nil {
+				summary, err = nil, fmt.Errorf("报告写入失败: %w", closeErr)
+			}
+		}()
+		summary.ReportFile = p.opts.ReportPath
+	}
+
+	// 分析结果按 Formatter 逐个输出，xlsx 等需要全部结果的格式在所有行处理完后输出
+	var results output.Stream
+	if p.opts.Formatter != nil {
+		out := p.opts.FormatterOut
+		if out == nil {
+			out = os.Stdout
+		}
+		results = output.NewStream(p.opts.Formatter, out)
+		defer func() {
+			if closeErr := results.Close(); closeErr != nil && err == nil {
+				summary, err = nil, fmt.Errorf("结果输出失败: %w", closeErr)
+			}
+		}()
+	}
+
+	for _, sheet := range sheets {
+		if sheet.Name != "" && len(sheets) > 1 {
+			p.logf("📄 Sheet: %s\n", sheet.Name)
+		}
+		if err := p.processSheet(ctx, sheet, cp, completed, rep, results, summary); err != nil {
+			if sheet.Name != "" {
+				return nil, fmt.Errorf("工作表 %s: %w", sheet.Name, err)
+			}
+			return nil, err
+		}
+	}
+
+	summary.Shared = FindSharedRepositories(summary.Rows)
+	p.logShared(summary.Shared)
+	if rep != nil {
+		for _, shared := range summary.Shared {
+			if err := rep.appendShared(shared); err != nil {
+				p.logf("⚠️  Failed to write report: %v\n", err)
+			}
+		}
+	}
+	return summary, nil
+}
+
+// processSheet 处理单个工作表，结果追加到 summary，results 非空时逐个输出各仓库的分析结果
+func (p *Processor) processSheet(ctx context.Context, sheet *Sheet, cp *checkpoint, completed map[checkpointKey]*RowResult,
+	rep *report, results output.Stream, summary *Summary) error {
+	records := sheet.Records
+	header := p.opts.HeaderRow - 1
+	if len(records) < header+2 {
+		return fmt.Errorf("文件至少需要包含表头（第%d行）和一行数据", header+1)
+	}
+
+	cols, err := p.prepareColumns(records, header)
+	if err != nil {
+		return err
+	}
+	sheet.headerRow = header
+	sheet.statusColumns = []int{cols.access, cols.submission}
+	sheet.resultColumns = append([]int{cols.access, cols.submission}, cols.extraIndexes()...)
+	if cols.penalty != -1 {
+		sheet.resultColumns = append(sheet.resultColumns, cols.penalty)
+	}
+
+	startRow, endRow, err := p.rowRange(len(records), header)
+	if err != nil {
+		return err
+	}
+
+	p.logf("📊 Processing %d records (data rows %d to %d)...\n\n", endRow-startRow+1, startRow, endRow)
+
+	p.processRows(ctx, sheet, startRow-1, endRow, cols, cp, completed, func(row *RowResult, log []byte) {
+		// 按行号顺序输出每一行的完整日志，并发时也不会交错
+		p.log.Write(log)
+		if results != nil {
+			for _, entry := range rowEntries(row) {
+				if err := results.Write(entry); err != nil {
+					p.logf("⚠️  Failed to format result of row %d: %v\n", row.Row, err)
+				}
+			}
+		}
+
+		if rep != nil {
+			if err := rep.append(row); err != nil {
+				p.logf("⚠️  Failed to write report for row %d: %v\n", row.Row, err)
+			}
+		}
+
+		summary.Rows = append(summary.Rows, row)
+		if row.Skipped {
+			summary.Skipped++
+		} else {
+			summary.Processed++
+		}
+	})
+
+	return nil
+}
+
+// rowRange 返回要处理的行号范围 [startRow, endRow]（1-based，包含）
+// header 为表头所在行的索引，total 为表格总行数
+func (p *Processor) rowRange(total, header int) (startRow, endRow int, err error) {
+	startRow = p.opts.StartRow
+	if startRow == 0 {
+		startRow = header + 2
+	}
+	endRow = p.opts.EndRow
+	if endRow == 0 {
+		endRow = total
+	}
+
+	// 验证行号参数
+	if startRow < header+2 {
+		return 0, 0, fmt.Errorf("start row must be >= %d (row %d is header)", header+2, header+1)
+	}
+	if endRow < startRow {
+		return 0, 0, fmt.Errorf("end row must be >= start row")
+	}
+	if endRow > total {
+		return 0, 0, fmt.Errorf("end row %d exceeds total rows %d", endRow, total)
+	}
+	return startRow, endRow, nil
+}
+
+// processRows 用固定数量的 worker 并发处理 [start, end) 范围的行
+// 每行处理完成后立即写入断点记录；done 回调在调用方 goroutine 中按行号顺序执行
+func (p *Processor) processRows(ctx context.Context, sheet *Sheet, start, end int, cols columns,
+	cp *checkpoint, completed map[checkpointKey]*RowResult, done func(row *RowResult, log []byte)) {
+	records := sheet.Records
+	type rowOutput struct {
+		row *RowResult
+		log bytes.Buffer
+	}
+
+	outputs := make([]*rowOutput, end-start)
+	finished := make([]chan struct{}, end-start)
+	for i := range finished {
+		outputs[i] = &rowOutput{}
+		finished[i] = make(chan struct{})
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < p.opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				out := outputs[i-start]
+				record := records[i]
+
+				if row, ok := completed[checkpointKey{sheet: sheet.Name, row: i + 1, repoURL: record[cols.repo]}]; ok {
+					row.Restored = true
+					out.row = row
+					fmt.Fprintf(&out.log, "♻️  Row %d restored from checkpoint: %s\n\n", i+1, row.RepoURL)
+				} else {
+					out.row = p.processRow(ctx, logger{&out.log}, i+1, record, cols)
+					out.row.Sheet = sheet.Name
+					if cp != nil {
+						if err := cp.append(out.row); err != nil {
+							fmt.Fprintf(&out.log, "⚠️  Failed to write checkpoint: %v\n\n", err)
+						}
+					}
+				}
+
+				// 每个 worker 只修改自己负责的行，互不干扰
+				writeRow(record, cols, out.row, p.opts.Location)
+				close(finished[i-start])
+			}
+		}()
+	}
+
+	go func() {
+		for i := start; i < end; i++ {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	for i := range outputs {
+		<-finished[i]
+		done(outputs[i].row, outputs[i].log.Bytes())
+	}
+	wg.Wait()
+}
+
+// client 获取（并缓存）平台客户端
+func (p *Processor) client(pl models.Platform) (api.Client, error) {
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+
+	if client, ok := p.clients[pl]; ok {
+		return client, nil
+	}
+	client, err := p.opts.NewClient(pl)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[pl] = client
+	return client, nil
+}
+
+// logf 输出处理日志
+func (p *Processor) logf(format string, args ...interface{}) {
+	fmt.Fprintf(p.log, format, args...)
+}
+
+// logger 单行处理日志，先写入缓冲区，处理完成后按行号顺序输出
+type logger struct {
+	w io.Writer
+}
+
+// logf 输出一条行日志
+func (l logger) logf(format string, args ...interface{}) {
+	fmt.Fprintf(l.w, format, args...)
+}
+
+// maskToken 隐藏Token的敏感部分，只显示前几位和后几位
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return "****"
+	}
+	return token[:4] + "****" + token[len(token)-4:]
+}
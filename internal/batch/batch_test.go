@@ -0,0 +1,834 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/config"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+	"github.com/luoliwoshang/git-event-monitor/internal/webhook"
+)
+
+// fakeClient 测试用客户端，按仓库返回预设结果
+type fakeClient struct {
+	platform   models.Platform
+	missing    map[string]bool
+	results    map[string]*models.AnalysisResult
+	hasCommits map[string]bool
+	// unavailable 返回临时性错误（503）的仓库
+	unavailable map[string]bool
+	calls       []string
+	deadlines   map[string]string
+	// parents fork 仓库的上游仓库，infos 为预设的仓库信息
+	parents map[string]string
+	infos   map[string]*models.Repository
+	// branches 各仓库分支截止时间前后的最新提交
+	branches map[string][]*models.BranchCommits
+	// requests 记录各仓库收到的分析请求
+	requests map[string]*models.AnalysisRequest
+}
+
+func (f *fakeClient) GetEvents(ctx context.Context, repo string, token string) ([]*models.UnifiedEvent, error) {
+	f.calls = append(f.calls, repo)
+	if f.missing[repo] {
+		return nil, &api.StatusError{StatusCode: 404}
+	}
+	if f.unavailable[repo] {
+		return nil, &api.StatusError{StatusCode: 503}
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) AnalyzeCodeEvents(ctx context.Context, req *models.AnalysisRequest) (*models.AnalysisResult, error) {
+	if f.deadlines != nil {
+		f.deadlines[req.Repository] = req.Deadline
+	}
+	if f.requests != nil {
+		f.requests[req.Repository] = req
+	}
+	if result, ok := f.results[req.Repository]; ok {
+		return result, nil
+	}
+	return &models.AnalysisResult{}, nil
+}
+
+func (f *fakeClient) HasCommits(ctx context.Context, repo string, token string) (bool, error) {
+	return f.hasCommits[repo], nil
+}
+
+func (f *fakeClient) GetBranchCommits(ctx context.Context, repo string, token string, deadline time.Time, patterns []string) ([]*models.BranchCommits, error) {
+	var selected []*models.BranchCommits
+	for _, branch := range f.branches[repo] {
+		if monitor.BranchAllowed(branch.Branch, patterns) {
+			selected = append(selected, branch)
+		}
+	}
+	return selected, nil
+}
+
+func (f *fakeClient) GetRepository(ctx context.Context, repo string, token string) (*models.Repository, error) {
+	if info, ok := f.infos[repo]; ok {
+		return info, nil
+	}
+	parent := f.parents[repo]
+	return &models.Repository{FullName: repo, Visibility: models.VisibilityPublic, Fork: parent != "", Parent: parent}, nil
+}
+
+func (f *fakeClient) GetPlatform() models.Platform {
+	return f.platform
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestProcessor_Process(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		missing:  map[string]bool{"team/private": true},
+		results: map[string]*models.AnalysisResult{
+			"team/ontime": {Found: true, SubmittedBefore: boolPtr(true)},
+			"team/late":   {Found: true, SubmittedBefore: boolPtr(false)},
+		},
+		hasCommits: map[string]bool{"team/initial": true},
+	}
+
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "https://github.com/team/ontime"},
+		{"B", "https://github.com/team/late.git"},
+		{"C", "github.com/team/private"},
+		{"D", "https://github.com/team/initial"},
+		{"E", "https://github.com/team/empty"},
+		{"F", "https://example.com/team/project"},
+		{"G"},
+	}
+
+	p := NewProcessor(Options{
+		Deadline:  "2025-09-30T23:59:59+08:00",
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	if got := records[0]; len(got) != 4 || got[2] != ColumnAccess || got[3] != ColumnSubmission {
+		t.Fatalf("Expected result columns to be appended, got %v", got)
+	}
+
+	expected := [][2]string{
+		{StatusAccessible, StatusOnTime},
+		{StatusAccessible, StatusLate},
+		{StatusInaccessible, ""},
+		{StatusAccessible, StatusInitialCommit},
+		{StatusAccessible, StatusEmptyRepo},
+		{"", ""},
+		{"", ""},
+	}
+	for i, want := range expected {
+		record := records[i+1]
+		if record[2] != StatusText(want[0]) || record[3] != StatusText(want[1]) {
+			t.Errorf("Row %d: expected %v, got %v", i+2, want, record[2:])
+		}
+	}
+
+	if summary.Processed != 5 || summary.Skipped != 2 {
+		t.Errorf("Expected 5 processed and 2 skipped, got %d and %d", summary.Processed, summary.Skipped)
+	}
+}
+
+func TestProcessor_MissingRepositoryColumn(t *testing.T) {
+	p := NewProcessor(Options{Log: io.Discard})
+	_, err := p.Process(context.Background(), [][]string{{"姓名"}, {"A"}})
+	if err == nil {
+		t.Fatal("Expected error when repository column is missing")
+	}
+}
+
+func TestProcessor_InvalidRowRange(t *testing.T) {
+	records := [][]string{{"代码仓库地址"}, {"https://github.com/team/project"}}
+
+	p := NewProcessor(Options{StartRow: 2, EndRow: 5, Log: io.Discard})
+	if _, err := p.Process(context.Background(), records); err == nil {
+		t.Fatal("Expected error when end row exceeds total rows")
+	}
+}
+
+func TestProcessor_ConcurrentOutputIsOrdered(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		results:  map[string]*models.AnalysisResult{},
+	}
+
+	records := [][]string{{"代码仓库地址"}}
+	for i := 0; i < 20; i++ {
+		repo := fmt.Sprintf("team/repo%d", i)
+		records = append(records, []string{"https://github.com/" + repo})
+		client.results[repo] = &models.AnalysisResult{Found: true, SubmittedBefore: boolPtr(i%2 == 0)}
+	}
+
+	run := func(concurrency int) (string, [][]string) {
+		rows := make([][]string, len(records))
+		for i, record := range records {
+			rows[i] = append([]string(nil), record...)
+		}
+
+		var log strings.Builder
+		p := NewProcessor(Options{
+			Deadline:    "2025-09-30T23:59:59+08:00",
+			NewClient:   func(models.Platform) (api.Client, error) { return client, nil },
+			Log:         &log,
+			Concurrency: concurrency,
+		})
+		if _, err := p.Process(context.Background(), rows); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		return log.String(), rows
+	}
+
+	sequentialLog, sequentialRows := run(1)
+	concurrentLog, concurrentRows := run(8)
+
+	if sequentialLog != concurrentLog {
+		t.Errorf("Expected identical logs, got:\n%s\nvs\n%s", sequentialLog, concurrentLog)
+	}
+	for i := range sequentialRows {
+		if strings.Join(sequentialRows[i], ",") != strings.Join(concurrentRows[i], ",") {
+			t.Errorf("Row %d differs: %v vs %v", i+1, sequentialRows[i], concurrentRows[i])
+		}
+	}
+}
+
+func TestProcessor_ResumeFromCheckpoint(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		missing:  map[string]bool{"team/private": true},
+		results: map[string]*models.AnalysisResult{
+			"team/ontime": {Found: true, SubmittedBefore: boolPtr(true)},
+			"team/late":   {Found: true, SubmittedBefore: boolPtr(false)},
+		},
+		unavailable: map[string]bool{"team/late": true},
+	}
+	newRecords := func() [][]string {
+		return [][]string{
+			{"姓名", "代码仓库地址"},
+			{"A", "https://github.com/team/ontime"},
+			{"B", "https://github.com/team/late"},
+			{"C", "https://github.com/team/private"},
+			{"D", "https://github.com/team/later"},
+		}
+	}
+	checkpointPath := filepath.Join(t.TempDir(), "sheet.checkpoint.jsonl")
+	run := func(records [][]string, checkpointPath string, resume bool) {
+		p := NewProcessor(Options{
+			Deadline:       "2025-09-30T23:59:59+08:00",
+			NewClient:      func(models.Platform) (api.Client, error) { return client, nil },
+			Log:            io.Discard,
+			CheckpointPath: checkpointPath,
+			Resume:         resume,
+		})
+		if _, err := p.Process(context.Background(), records); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+	}
+
+	// 第一次运行：B 遇到临时性错误
+	run(newRecords(), checkpointPath, false)
+
+	// 模拟在处理 D 时中断：去掉最后一条记录，并留下写了一半的行
+	data, err := os.ReadFile(checkpointPath)
+	if err != nil {
+		t.Fatalf("Failed to read checkpoint: %v", err)
+	}
+	lines := strings.SplitAfter(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected a header and 4 checkpoint lines, got %d", len(lines))
+	}
+	partial := strings.Join(lines[:4], "") + lines[4][:len(lines[4])/2]
+	if err := os.WriteFile(checkpointPath, []byte(partial), 0o644); err != nil {
+		t.Fatalf("Failed to write checkpoint: %v", err)
+	}
+
+	// 续跑：A、C 从断点恢复，B（临时失败）和 D（未完成）重新处理
+	client.unavailable = nil
+	client.calls = nil
+	resumed := newRecords()
+	run(resumed, checkpointPath, true)
+
+	if got := strings.Join(client.calls, ","); got != "team/late,team/later" {
+		t.Errorf("Expected only B and D to be checked again, got %s", got)
+	}
+
+	// 再次续跑：D 的记录没有接在写了一半的行后面，所有行都从断点恢复
+	client.calls = nil
+	run(newRecords(), checkpointPath, true)
+	if len(client.calls) != 0 {
+		t.Errorf("Expected every row to be restored on the second resume, got %v", client.calls)
+	}
+
+	expected := newRecords()
+	run(expected, "", false)
+	for i := range expected {
+		if strings.Join(resumed[i], ",") != strings.Join(expected[i], ",") {
+			t.Errorf("Row %d differs from uninterrupted run: %v vs %v", i+1, resumed[i], expected[i])
+		}
+	}
+}
+
+func TestProcessor_ResumeWithChangedOptions(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		results:  map[string]*models.AnalysisResult{"team/ontime": {Found: true, SubmittedBefore: boolPtr(true)}},
+	}
+	checkpointPath := filepath.Join(t.TempDir(), "sheet.checkpoint.jsonl")
+	run := func(deadline string, resume bool) error {
+		p := NewProcessor(Options{
+			Deadline:       deadline,
+			NewClient:      func(models.Platform) (api.Client, error) { return client, nil },
+			Log:            io.Discard,
+			CheckpointPath: checkpointPath,
+			Resume:         resume,
+		})
+		_, err := p.Process(context.Background(), [][]string{{"姓名", "代码仓库地址"}, {"A", "https://github.com/team/ontime"}})
+		return err
+	}
+
+	if err := run("2025-09-30T23:59:59+08:00", false); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if err := run("2025-09-30T23:59:59+08:00", true); err != nil {
+		t.Errorf("Expected resume with the same options to succeed, got %v", err)
+	}
+	// 截止时间改变后，断点记录中的结论不再适用
+	if err := run("2025-10-01T23:59:59+08:00", true); err == nil {
+		t.Error("Expected resume with a different deadline to be refused")
+	}
+}
+
+func TestLoadCheckpoint_MissingHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sheet.checkpoint.jsonl")
+	line := `{"row":2,"repo_url":"https://github.com/team/a","access":"accessible","submission":"on_time"}` + "\n"
+	if err := os.WriteFile(path, []byte(line), 0o644); err != nil {
+		t.Fatalf("Failed to write checkpoint: %v", err)
+	}
+
+	if _, _, err := openCheckpoint(path, true, Options{}.fingerprint()); err == nil {
+		t.Error("Expected a checkpoint without header to be refused")
+	}
+}
+
+func TestProcessor_MultipleRepositories(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		missing:  map[string]bool{"team/private": true},
+		results: map[string]*models.AnalysisResult{
+			"team/web":    {Found: true, SubmittedBefore: boolPtr(true)},
+			"team/api":    {Found: true, SubmittedBefore: boolPtr(true)},
+			"team/late":   {Found: true, SubmittedBefore: boolPtr(false)},
+			"team/notime": {Found: true},
+		},
+	}
+
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "前端：https://github.com/team/web\n后端：https://github.com/team/api"},
+		{"B", "https://github.com/team/web，https://github.com/team/late"},
+		{"C", "https://github.com/team/web; https://github.com/team/private"},
+		{"D", "https://github.com/team/notime、https://github.com/team/web"},
+		{"E", "https://github.com/team/web https://github.com/team/web.git"},
+	}
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "sheet.csv")
+	if err := writeCSVFile(input, records); err != nil {
+		t.Fatalf("Failed to write input: %v", err)
+	}
+
+	summary, err := Run(context.Background(), input, Options{
+		Deadline:  "2025-09-30T23:59:59+08:00",
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	output, err := ReadFile(summary.OutputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	expected := [][2]string{
+		{StatusAccessible, StatusOnTime},
+		{StatusAccessible, StatusLate},
+		{StatusInaccessible, ""},
+		{StatusAccessible, StatusUndetermined},
+		{StatusAccessible, StatusOnTime},
+	}
+	for i, want := range expected {
+		record := output[i+1]
+		if record[2] != StatusText(want[0]) || record[3] != StatusText(want[1]) {
+			t.Errorf("Row %d: expected %v, got %v", i+2, want, record[2:])
+		}
+	}
+
+	if got := len(summary.Rows[4].Repos); got != 1 {
+		t.Errorf("Expected duplicate repository to be checked once, got %d", got)
+	}
+
+	details, err := ReadFile(filepath.Join(dir, "sheet_processed_repos.csv"))
+	if err != nil {
+		t.Fatalf("Failed to read details: %v", err)
+	}
+	if len(details) != 10 {
+		t.Fatalf("Expected header and 9 repository rows, got %d", len(details))
+	}
+	if got := details[6]; got[0] != "4" || got[5] != "team/private" || got[6] != StatusText(StatusInaccessible) {
+		t.Errorf("Unexpected detail row: %v", got)
+	}
+}
+
+func TestProcessor_RepositoryFlags(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		infos: map[string]*models.Repository{
+			"team/old":     {FullName: "team/old", Visibility: models.VisibilityPublic, CreatedAt: "2025-08-01T00:00:00Z"},
+			"team/private": {FullName: "team/private", Visibility: models.VisibilityPrivate, CreatedAt: "2025-09-05T00:00:00Z", Archived: true},
+			"team/new":     {FullName: "team/new", Visibility: models.VisibilityPublic, CreatedAt: "2025-09-05T00:00:00Z"},
+		},
+	}
+
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "https://github.com/team/old"},
+		{"B", "https://github.com/team/private"},
+		{"C", "https://github.com/team/new"},
+	}
+
+	p := NewProcessor(Options{
+		Start:     time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	want := []string{"created_before_start", "private, archived", ""}
+	for i, row := range summary.Rows {
+		repo := row.Repos[0]
+		if got := strings.Join(repo.Flags, ", "); got != want[i] {
+			t.Errorf("Row %d: expected flags %q, got %q", row.Row, want[i], got)
+		}
+		if repo.Info == nil || repo.Info.FullName != repo.Repository {
+			t.Errorf("Row %d: expected repository info, got %+v", row.Row, repo.Info)
+		}
+	}
+}
+
+func TestProcessor_SkipRepoInfo(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		infos: map[string]*models.Repository{
+			"team/private": {FullName: "team/private", Visibility: models.VisibilityPrivate},
+		},
+	}
+
+	p := NewProcessor(Options{
+		SkipRepoInfo: true,
+		NewClient:    func(models.Platform) (api.Client, error) { return client, nil },
+		Log:          io.Discard,
+	})
+	summary, err := p.Process(context.Background(), [][]string{{"姓名", "代码仓库地址"}, {"B", "https://github.com/team/private"}})
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if repo := summary.Rows[0].Repos[0]; repo.Info != nil || len(repo.Flags) != 0 {
+		t.Errorf("Expected no repository info and no flags, got %+v / %v", repo.Info, repo.Flags)
+	}
+}
+
+func TestProcessor_HTMLOutputIsSingleDocument(t *testing.T) {
+	client := &fakeClient{platform: models.PlatformGitHub}
+	var out bytes.Buffer
+	p := NewProcessor(Options{
+		Deadline:     "2025-09-30T23:59:59+08:00",
+		Formatter:    &output.HTMLFormatter{Location: time.UTC},
+		FormatterOut: &out,
+		NewClient:    func(models.Platform) (api.Client, error) { return client, nil },
+		Log:          io.Discard,
+	})
+	records := [][]string{{"代码仓库地址"}, {"https://github.com/team/one"}, {"https://github.com/team/two"}}
+	if _, err := p.Process(context.Background(), records); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	// 所有行的结果汇总为一个页面，而不是每行一个页面
+	page := out.String()
+	if strings.Count(page, "<html") != 1 || strings.Count(page, "</html>") != 1 {
+		t.Fatalf("Expected a single HTML document, got:\n%s", page)
+	}
+	for _, repo := range []string{"team/one", "team/two"} {
+		if !strings.Contains(page, repo) {
+			t.Errorf("Expected the page to list %s", repo)
+		}
+	}
+}
+
+func TestProcessor_WebhookEvidence(t *testing.T) {
+	onTime := true
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		results: map[string]*models.AnalysisResult{
+			"team/project": {Found: true, SubmittedBefore: &onTime, Confidence: models.ConfidenceHigh},
+		},
+	}
+	late := &models.UnifiedEvent{
+		BaseEvent: models.BaseEvent{Type: "PushEvent", CreatedAt: "2025-10-01T00:00:00Z"},
+		RepoName:  "team/project",
+		Payload:   map[string]interface{}{"ref": "refs/heads/main"},
+	}
+
+	p := NewProcessor(Options{
+		Deadline:  "2025-09-30T23:59:59+08:00",
+		Webhooks:  []*webhook.Record{{Platform: models.PlatformGitHub, HookEvent: "push", Event: late}},
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	summary, err := p.Process(context.Background(), [][]string{{"姓名", "代码仓库地址"}, {"A", "https://github.com/team/project"}})
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	row := summary.Rows[0]
+	if row.Submission != StatusLate || row.Repos[0].Verdict.Status != models.VerdictLate {
+		t.Errorf("Expected the webhook push after the deadline to make the row late, got %s / %+v", row.Submission, row.Repos[0].Verdict)
+	}
+}
+
+func TestProcessor_PushedBeforeStart(t *testing.T) {
+	earliest := &models.UnifiedEvent{BaseEvent: models.BaseEvent{Type: "PushEvent", CreatedAt: "2025-08-28T10:00:00Z"}}
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		results: map[string]*models.AnalysisResult{
+			"team/early":    {Found: true, EarliestCodeEvent: earliest, PushesBeforeStart: 2, CommitsBeforeStart: 5},
+			"team/scaffold": {Found: true, EarliestCodeEvent: earliest, PushesBeforeStart: 1, CommitsBeforeStart: 1},
+		},
+		requests: make(map[string]*models.AnalysisRequest),
+	}
+
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "https://github.com/team/early"},
+		{"B", "https://github.com/team/scaffold"},
+	}
+
+	p := NewProcessor(Options{
+		Start:     time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	if req := client.requests["team/early"]; req == nil || req.Start != "2025-09-01T00:00:00Z" {
+		t.Fatalf("Expected analysis with start time, got %+v", req)
+	}
+	want := []string{"pushed_before_start", ""}
+	for i, row := range summary.Rows {
+		repo := row.Repos[0]
+		if got := strings.Join(repo.Flags, ", "); got != want[i] {
+			t.Errorf("Row %d: expected flags %q, got %q", row.Row, want[i], got)
+		}
+		if repo.Submission != StatusNoDeadline {
+			t.Errorf("Row %d: expected %s without a deadline, got %s", row.Row, StatusNoDeadline, repo.Submission)
+		}
+	}
+}
+
+func TestProcessor_BranchCommitFallback(t *testing.T) {
+	commit := func(sha, committedAt string) *models.Commit {
+		return &models.Commit{SHA: sha, CommittedAt: committedAt}
+	}
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		branches: map[string][]*models.BranchCommits{
+			"team/ontime": {{Branch: "main", Before: commit("aaa", "2025-09-30T10:00:00+08:00")}},
+			"team/late": {
+				{Branch: "main", Before: commit("bbb", "2025-09-30T10:00:00+08:00")},
+				{Branch: "dev", After: commit("ccc", "2025-10-01T10:00:00+08:00")},
+			},
+			"team/nocommits": {{Branch: "main"}},
+		},
+	}
+
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "https://github.com/team/ontime"},
+		{"B", "https://github.com/team/late"},
+		{"C", "https://github.com/team/nocommits"},
+		{"D", "https://github.com/team/ontime https://github.com/team/late"},
+	}
+
+	p := NewProcessor(Options{
+		Deadline:  "2025-09-30T23:59:59+08:00",
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	want := []string{StatusOnTimeLowConfidence, StatusLateLowConfidence, StatusEmptyRepo, StatusLateLowConfidence}
+	for i, row := range summary.Rows {
+		if row.Submission != want[i] {
+			t.Errorf("Row %d: expected %q, got %q", row.Row, want[i], row.Submission)
+		}
+	}
+
+	result := summary.Rows[1].Repos[0].Result
+	if result.Confidence != models.ConfidenceLow || len(result.BranchCommits) != 2 {
+		t.Errorf("Expected low confidence result with branch commits, got %+v", result)
+	}
+	if verdict, confidence := rowVerdict(summary.Rows[0]); verdict != VerdictOnTime || confidence != models.ConfidenceLow {
+		t.Errorf("Expected on_time/low verdict, got %s/%s", verdict, confidence)
+	}
+	if verdict, _ := rowVerdict(summary.Rows[2]); verdict != VerdictEmpty {
+		t.Errorf("Expected empty verdict, got %s", verdict)
+	}
+	evidence := summary.Rows[1].Repos[0].Verdict.Evidence
+	if len(evidence) == 0 || evidence[0].Source != models.EvidenceCommit {
+		t.Errorf("Expected commit evidence, got %+v", evidence)
+	}
+}
+
+func TestProcessor_Contest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contest.yaml")
+	content := `
+default_track: web
+tracks:
+  - name: web
+    deadline: "2025-09-30 23:59"
+    timezone: Asia/Shanghai
+    grace: 15m
+  - name: ai
+    deadline: "2025-10-07T18:00:00Z"
+    start: "2025-10-01T00:00:00Z"
+    branches: [main]
+participants:
+  - name: A
+    repos: [https://github.com/team/ai]
+    track: ai
+  - name: B
+    track: ai
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write contest file: %v", err)
+	}
+	contest, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		requests: map[string]*models.AnalysisRequest{},
+		infos: map[string]*models.Repository{
+			"team/ai": {FullName: "team/ai", Visibility: models.VisibilityPublic, CreatedAt: "2025-09-20T00:00:00Z"},
+		},
+	}
+	records := [][]string{
+		{"姓名", "代码仓库地址", "截止时间"},
+		{"A", "https://github.com/team/ai", ""},
+		{"B", "https://github.com/team/by-name", ""},
+		{"C", "https://github.com/team/unlisted", ""},
+		{"D", "https://github.com/team/cell", "2025-09-01T00:00:00Z"},
+	}
+
+	mapping := DefaultColumnMapping()
+	mapping.Deadline = ColumnSelector{Header: "截止时间"}
+	p := NewProcessor(Options{
+		Columns:   mapping,
+		Contest:   contest,
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	tests := []struct {
+		repo, track, deadline string
+		branches              int
+	}{
+		{"team/ai", "ai", "2025-10-07T18:00:00Z", 1},
+		{"team/by-name", "ai", "2025-10-07T18:00:00Z", 1},
+		{"team/unlisted", "web", "2025-09-30T23:59:00+08:00", 0},
+		{"team/cell", "web", "2025-09-01T00:00:00Z", 0},
+	}
+	for i, tt := range tests {
+		req := client.requests[tt.repo]
+		if req == nil {
+			t.Fatalf("%s: not analysed", tt.repo)
+		}
+		if req.Deadline != tt.deadline || len(req.Branches) != tt.branches {
+			t.Errorf("%s: unexpected request %+v", tt.repo, req)
+		}
+		if row := summary.Rows[i]; row.Track != tt.track || row.Deadline != tt.deadline {
+			t.Errorf("%s: expected track %s and deadline %s, got %s / %s", tt.repo, tt.track, tt.deadline, row.Track, row.Deadline)
+		}
+	}
+
+	// 没有指定 Options.Start 时使用赛道的开始时间
+	if flags := summary.Rows[0].Repos[0].Flags; len(flags) != 1 || flags[0] != monitor.FlagCreatedBeforeStart {
+		t.Errorf("Expected created_before_start flag from the track start, got %v", flags)
+	}
+}
+
+func TestProcessor_ContestCommandLineOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contest.yaml")
+	content := `
+default_track: web
+tracks:
+  - name: web
+    deadline: "2025-09-30T16:00:00Z"
+    start: "2025-09-01T00:00:00Z"
+    grace: 15m
+    penalties:
+      - { after: 1h, points: 10 }
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write contest file: %v", err)
+	}
+	contest, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	push := func(createdAt string) *models.AnalysisResult {
+		return &models.AnalysisResult{
+			Found:           true,
+			LastCodeEvent:   &models.UnifiedEvent{BaseEvent: models.BaseEvent{Type: "PushEvent", CreatedAt: createdAt}},
+			SubmittedBefore: boolPtr(false),
+		}
+	}
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		requests: map[string]*models.AnalysisRequest{},
+		results: map[string]*models.AnalysisResult{
+			"team/grace": push("2025-10-01T00:20:00Z"),
+			"team/late":  push("2025-10-01T02:00:00Z"),
+		},
+		infos: map[string]*models.Repository{
+			"team/grace": {FullName: "team/grace", Visibility: models.VisibilityPublic, CreatedAt: "2025-09-20T00:00:00Z"},
+		},
+	}
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "https://github.com/team/grace"},
+		{"B", "https://github.com/team/late"},
+	}
+
+	// 命令行的截止时间、开始时间和宽限时间优先于赛道，没有指定扣分档位时保留赛道的扣分档位
+	p := NewProcessor(Options{
+		Deadline:  "2025-10-01T00:00:00Z",
+		Start:     time.Date(2025, 9, 25, 0, 0, 0, 0, time.UTC),
+		Policy:    models.LatenessPolicy{Grace: 30 * time.Minute},
+		GraceSet:  true,
+		Contest:   contest,
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	for _, repo := range []string{"team/grace", "team/late"} {
+		if req := client.requests[repo]; req == nil || req.Deadline != "2025-10-01T00:00:00Z" {
+			t.Errorf("%s: expected the --deadline value, got %+v", repo, req)
+		}
+	}
+	if row := summary.Rows[0]; row.Submission != StatusOnTime || row.Lateness == nil || row.Lateness.Tier != models.TierGrace {
+		t.Errorf("Row 2: expected on time within the --grace period, got %s %+v", row.Submission, row.Lateness)
+	}
+	if row := summary.Rows[1]; row.Submission != StatusLate || row.Lateness == nil || row.Lateness.Penalty != 10 {
+		t.Errorf("Row 3: expected the track penalty tier, got %s %+v", row.Submission, row.Lateness)
+	}
+	if flags := summary.Rows[0].Repos[0].Flags; len(flags) != 1 || flags[0] != monitor.FlagCreatedBeforeStart {
+		t.Errorf("Expected created_before_start flag from --start, got %v", flags)
+	}
+}
+
+func TestProcessor_Penalty(t *testing.T) {
+	push := func(createdAt string, onTime bool) *models.AnalysisResult {
+		return &models.AnalysisResult{
+			Found:           true,
+			LastCodeEvent:   &models.UnifiedEvent{BaseEvent: models.BaseEvent{Type: "PushEvent", CreatedAt: createdAt}},
+			SubmittedBefore: boolPtr(onTime),
+		}
+	}
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		results: map[string]*models.AnalysisResult{
+			"team/ontime": push("2025-09-30T15:00:00Z", true),
+			"team/grace":  push("2025-09-30T16:10:00Z", false),
+			"team/late":   push("2025-09-30T18:30:00Z", false),
+		},
+	}
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "https://github.com/team/ontime"},
+		{"B", "https://github.com/team/grace"},
+		{"C", "https://github.com/team/late https://github.com/team/ontime"},
+	}
+
+	p := NewProcessor(Options{
+		Deadline: "2025-09-30T16:00:00Z",
+		Policy: models.LatenessPolicy{
+			Grace: 15 * time.Minute,
+			Tiers: []models.PenaltyTier{{Name: "+15m", After: 15 * time.Minute, PerHour: 5}},
+		},
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	penalty := findExactColumn(records[0], ColumnPenalty)
+	if penalty == -1 {
+		t.Fatalf("Expected penalty column, got %v", records[0])
+	}
+	want := []struct{ submission, penalty, tier string }{
+		{StatusOnTime, "0", models.TierOnTime},
+		{StatusOnTime, "0", models.TierGrace},
+		{StatusLate, "15", "+15m"},
+	}
+	for i, w := range want {
+		record, row := records[i+1], summary.Rows[i]
+		if record[3] != StatusText(w.submission) || record[penalty] != w.penalty {
+			t.Errorf("Row %d: expected %s / %s, got %v", i+2, w.submission, w.penalty, record)
+		}
+		if row.Lateness == nil || row.Lateness.Tier != w.tier {
+			t.Errorf("Row %d: expected tier %s, got %+v", i+2, w.tier, row.Lateness)
+		}
+	}
+}
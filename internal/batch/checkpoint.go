@@ -0,0 +1,182 @@
+package batch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/config"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// checkpointKey 断点记录的键：工作表 + 行号 + 仓库地址单元格原文
+// 表格在两次运行之间被修改时，对应行会重新处理
+type checkpointKey struct {
+	sheet   string
+	row     int
+	repoURL string
+}
+
+// checkpoint 断点记录文件（JSONL，第一行为 checkpointHeader，之后每处理完一行追加一条 RowResult）
+type checkpoint struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// checkpointHeader 断点记录文件的第一行
+type checkpointHeader struct {
+	// Fingerprint 生成断点记录时影响检查结果的选项的摘要（见 Options.fingerprint）
+	Fingerprint string `json:"fingerprint"`
+}
+
+// fingerprint 返回影响检查结果的选项的摘要
+// 截止时间、开始时间、时区、宽限和扣分、比赛配置、列映射、表头行或是否查询仓库信息改变后，已记录的结果不再适用；
+// 行范围、并发数、可选结果列等只影响处理范围和显示的选项不计入
+func (o Options) fingerprint() string {
+	var start, location string
+	if !o.Start.IsZero() {
+		start = o.Start.UTC().Format(time.RFC3339)
+	}
+	if o.Location != nil {
+		location = o.Location.String()
+	}
+	columns := o.Columns
+	columns.Extra = nil
+
+	data, _ := json.Marshal(struct {
+		Deadline  string                `json:"deadline"`
+		Start     string                `json:"start"`
+		Location  string                `json:"location"`
+		Policy    models.LatenessPolicy `json:"policy"`
+		PolicySet [2]bool               `json:"policy_set"`
+		Contest   *config.Contest       `json:"contest"`
+		Columns   ColumnMapping         `json:"columns"`
+		HeaderRow int                   `json:"header_row"`
+		NoInfo    bool                  `json:"skip_repo_info"`
+		Webhooks  int                   `json:"webhook_records"`
+		Commits   int                   `json:"commits_before_start"`
+	}{o.Deadline, start, location, o.Policy, [2]bool{o.GraceSet, o.PenaltySet}, o.Contest, columns, o.HeaderRow, o.SkipRepoInfo, len(o.Webhooks), o.CommitsBeforeStart})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckpointPath 根据原文件名生成默认的断点记录文件名
+func CheckpointPath(originalFilename string) string {
+	return strings.TrimSuffix(originalFilename, filepath.Ext(originalFilename)) + ".checkpoint.jsonl"
+}
+
+// openCheckpoint 打开断点记录文件，fingerprint 为当前选项的摘要
+// resume 为 true 时读取已完成的行并在最后一个完整行之后继续追加，否则清空文件重新记录；
+// 断点记录缺少选项摘要或与 fingerprint 不一致时拒绝续跑
+func openCheckpoint(path string, resume bool, fingerprint string) (*checkpoint, map[checkpointKey]*RowResult, error) {
+	completed := make(map[checkpointKey]*RowResult)
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	writeHeader := true
+	var size int64
+	if resume {
+		header, rows, complete, err := loadCheckpoint(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if header != nil && header.Fingerprint != fingerprint {
+			return nil, nil, fmt.Errorf("断点文件 %s 生成时的截止时间、开始时间、比赛配置、扣分规则、列映射或 Webhook 记录与本次不同，请去掉 --resume 重新处理", path)
+		}
+		writeHeader, size = header == nil, complete
+		for _, row := range rows {
+			key := checkpointKey{sheet: row.Sheet, row: row.Row, repoURL: row.RepoURL}
+			if row.Transient {
+				// 临时性失败的行需要重新处理，后续的失败记录会覆盖之前的成功记录
+				delete(completed, key)
+				continue
+			}
+			completed[key] = row
+		}
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	file, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("无法打开断点文件: %w", err)
+	}
+
+	// 去掉中断时只写了一半的最后一行，否则追加的记录会接在它后面
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("断点文件写入失败: %w", err)
+	}
+
+	cp := &checkpoint{file: file}
+	if writeHeader {
+		data, _ := json.Marshal(checkpointHeader{Fingerprint: fingerprint})
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			file.Close()
+			return nil, nil, fmt.Errorf("断点文件写入失败: %w", err)
+		}
+	}
+	return cp, completed, nil
+}
+
+// loadCheckpoint 读取断点记录文件，文件不存在时返回空列表
+// complete 为最后一个完整行结束的位置；只有不完整的内容时 header 为 nil，
+// 有完整内容但第一行不是 checkpointHeader 时返回错误
+func loadCheckpoint(path string) (header *checkpointHeader, rows []*RowResult, complete int64, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, 0, nil
+	}
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("无法打开断点文件: %w", err)
+	}
+
+	// 中断时最后一行可能只写了一半，只读取到最后一个换行符为止
+	complete = int64(bytes.LastIndexByte(data, '\n') + 1)
+	for _, line := range strings.Split(string(data[:complete]), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if header == nil {
+			var h checkpointHeader
+			if json.Unmarshal([]byte(line), &h) != nil || h.Fingerprint == "" {
+				return nil, nil, 0, fmt.Errorf("断点文件 %s 缺少选项摘要，无法确认是否与本次选项一致，请去掉 --resume 重新处理", path)
+			}
+			header = &h
+			continue
+		}
+		var row RowResult
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return nil, nil, 0, fmt.Errorf("断点文件 %s 格式错误: %w", path, err)
+		}
+		rows = append(rows, &row)
+	}
+
+	return header, rows, complete, nil
+}
+
+// append 追加一行处理结果
+func (c *checkpoint) append(row *RowResult) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err = c.file.Write(append(data, '\n'))
+	return err
+}
+
+// Close 关闭断点记录文件
+func (c *checkpoint) Close() error {
+	return c.file.Close()
+}
@@ -0,0 +1,207 @@
+package batch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+)
+
+// 默认列名（未配置列映射时使用），不随语言变化，重新处理时按列名查找已有的结果列
+const (
+	ColumnRepository = "代码仓库地址"
+	ColumnName       = "姓名"
+	ColumnAccess     = "是否可访问"
+	ColumnSubmission = "是否准时提交"
+	// ColumnPenalty 扣分列，设置了扣分档位时添加
+	ColumnPenalty = "扣分"
+)
+
+// columns 表格中相关列的索引，未映射或找不到的列为 -1
+type columns struct {
+	repo       int
+	name       int
+	team       int
+	access     int
+	submission int
+	deadline   int
+	platform   int
+	penalty    int
+	extras     []extraIndex
+}
+
+// extraIndex 启用的可选结果列及其索引
+type extraIndex struct {
+	column *output.ResultColumn
+	index  int
+}
+
+// extraIndexes 返回所有可选结果列的索引
+func (c columns) extraIndexes() []int {
+	indexes := make([]int, len(c.extras))
+	for i, extra := range c.extras {
+		indexes[i] = extra.index
+	}
+	return indexes
+}
+
+// prepareColumns 按列映射查找相关列，结果列不存在时追加
+// header 为表头所在行的索引，之前的行不做修改
+func (p *Processor) prepareColumns(records [][]string, header int) (columns, error) {
+	mapping := p.opts.Columns
+	cols, err := p.findColumns(records[header])
+	if err != nil {
+		return cols, err
+	}
+
+	// Excel 读取时会省略行尾空单元格，先把所有行补齐到表头长度
+	table := records[header:]
+	padRecords(table)
+
+	if cols.access == -1 {
+		cols.access = p.addResultColumn(table, mapping.Access, ColumnAccess)
+	}
+	if cols.submission == -1 {
+		cols.submission = p.addResultColumn(table, mapping.Submission, ColumnSubmission)
+	}
+	if p.hasPenalties() {
+		if cols.penalty = findExactColumn(table[0], ColumnPenalty); cols.penalty == -1 {
+			cols.penalty = p.addResultColumn(table, ColumnSelector{}, ColumnPenalty)
+		}
+	}
+	for _, key := range mapping.Extra {
+		extra := output.FindResultColumn(key)
+		if extra == nil {
+			return cols, fmt.Errorf("unknown result column %q", key)
+		}
+		index := findExactColumn(table[0], extra.Header)
+		if index == -1 {
+			index = p.addResultColumn(table, ColumnSelector{Header: extra.Header}, extra.Header)
+		}
+		cols.extras = append(cols.extras, extraIndex{column: extra, index: index})
+	}
+
+	p.logf("📍 Column positions:\n")
+	for _, c := range []struct {
+		label string
+		index int
+	}{
+		{"Repository", cols.repo},
+		{"Name", cols.name},
+		{"Team", cols.team},
+		{"Deadline", cols.deadline},
+		{"Platform", cols.platform},
+		{"Access", cols.access},
+		{"Submission", cols.submission},
+		{"Penalty", cols.penalty},
+	} {
+		if c.index != -1 {
+			p.logf("  %s: column %d (%s)\n", c.label, c.index+1, table[0][c.index])
+		}
+	}
+	for _, extra := range cols.extras {
+		p.logf("  %s: column %d\n", extra.column.Header, extra.index+1)
+	}
+	p.logf("\n")
+
+	return cols, nil
+}
+
+// findColumns 按列映射在表头中查找相关列，不修改表格
+func (p *Processor) findColumns(headers []string) (columns, error) {
+	mapping := p.opts.Columns
+	cols := columns{
+		repo:       mapping.Repository.find(headers),
+		name:       mapping.Participant.find(headers),
+		team:       mapping.Team.find(headers),
+		access:     mapping.Access.find(headers),
+		submission: mapping.Submission.find(headers),
+		deadline:   mapping.Deadline.find(headers),
+		platform:   mapping.Platform.find(headers),
+		penalty:    -1,
+	}
+
+	if cols.repo == -1 {
+		return cols, fmt.Errorf("未找到'%s'列", mapping.Repository)
+	}
+	return cols, nil
+}
+
+// addResultColumn 添加结果列，返回列索引
+// 按列字母映射时使用该位置（必要时补齐中间的空列），否则追加到末尾
+func (p *Processor) addResultColumn(records [][]string, sel ColumnSelector, defaultName string) int {
+	name := sel.Header
+	if name == "" {
+		name = defaultName
+	}
+
+	index := -1
+	if sel.Letter != "" {
+		index, _ = columnLetterIndex(sel.Letter)
+		for len(records[0]) <= index {
+			appendColumn(records, "")
+		}
+		if records[0][index] == "" {
+			records[0][index] = name
+		}
+	} else {
+		index = appendColumn(records, name)
+	}
+
+	p.logf("📝 Added column: %s (column %d)\n", name, index+1)
+	return index
+}
+
+// cell 返回记录中指定列的值（去除首尾空白），列不存在时返回空字符串
+func cell(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// findColumnIndex 查找列的索引（表头包含列名即视为匹配）
+func findColumnIndex(headers []string, columnName string) int {
+	for i, header := range headers {
+		if strings.Contains(header, columnName) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findExactColumn 查找表头与列名完全相同的列，找不到时返回 -1
+func findExactColumn(headers []string, columnName string) int {
+	for i, header := range headers {
+		if strings.TrimSpace(header) == columnName {
+			return i
+		}
+	}
+	return -1
+}
+
+// padRecords 把所有数据行补齐到表头长度
+func padRecords(records [][]string) {
+	width := len(records[0])
+	for i := 1; i < len(records); i++ {
+		for len(records[i]) < width {
+			records[i] = append(records[i], "")
+		}
+	}
+}
+
+// appendColumn 在表头和所有数据行末尾追加一列，返回新列索引
+func appendColumn(records [][]string, columnName string) int {
+	records[0] = append(records[0], columnName)
+	for i := 1; i < len(records); i++ {
+		records[i] = append(records[i], "")
+	}
+	return len(records[0]) - 1
+}
+
+// updateRecord 更新记录中的指定列
+func updateRecord(record []string, columnIndex int, value string) {
+	if columnIndex != -1 && columnIndex < len(record) {
+		record[columnIndex] = value
+	}
+}
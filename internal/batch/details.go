@@ -0,0 +1,80 @@
+package batch
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+)
+
+// DetailsSheet 仓库明细工作表名称
+const DetailsSheet = "仓库明细"
+
+// detailHeaders 仓库明细表头
+var detailHeaders = output.RecordHeaders
+
+// detailStatusColumns 仓库明细中状态列的索引
+func detailStatusColumns(headers []string) []int {
+	return []int{findExactColumn(headers, ColumnAccess), findExactColumn(headers, ColumnSubmission)}
+}
+
+// DetailRecords 生成每个仓库一行的明细表（第一行为表头）
+// 处理了多个工作表时，第一列为工作表名称；extra 为启用的可选结果列，时间按 loc 显示；
+// 状态列与 check 的 csv/xlsx 输出一样由 output.RecordStatus 按结论给出，未检查的仓库留空
+func DetailRecords(rows []*RowResult, extra []string, loc *time.Location) [][]string {
+	multiSheet := false
+	for _, row := range rows {
+		if row.Sheet != rows[0].Sheet {
+			multiSheet = true
+			break
+		}
+	}
+
+	headers := append([]string(nil), detailHeaders...)
+	if multiSheet {
+		headers = append([]string{"工作表"}, headers...)
+	}
+	for _, key := range extra {
+		headers = append(headers, output.FindResultColumn(key).Header)
+	}
+
+	records := [][]string{headers}
+	for _, row := range rows {
+		for _, repo := range row.Repos {
+			result := repo.displayResult(row.Deadline)
+			var access, submission string
+			if repo.Access != "" {
+				access, submission = output.RecordStatus(result)
+			}
+			var record []string
+			if multiSheet {
+				record = append(record, row.Sheet)
+			}
+			record = append(record,
+				strconv.Itoa(row.Row),
+				row.Name,
+				row.Team,
+				repo.RepoURL,
+				string(repo.Platform),
+				repo.Repository,
+				access,
+				submission,
+			)
+			for _, key := range extra {
+				record = append(record, output.FindResultColumn(key).Value(result, loc))
+			}
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// hasMultiRepoRows 判断是否有填写了多个仓库的行
+func hasMultiRepoRows(rows []*RowResult) bool {
+	for _, row := range rows {
+		if len(row.Repos) > 1 {
+			return true
+		}
+	}
+	return false
+}
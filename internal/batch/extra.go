@@ -0,0 +1,62 @@
+package batch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+)
+
+// ExtraColumnKeys 返回所有可选结果列的名称和说明
+func ExtraColumnKeys() map[string]string {
+	keys := make(map[string]string, len(output.ResultColumns))
+	for _, c := range output.ResultColumns {
+		keys[c.Key] = c.Usage
+	}
+	return keys
+}
+
+// ParseExtraColumns 解析可选结果列名称列表，"all" 表示所有列
+// 返回按 output.ResultColumns 顺序排列的列名
+func ParseExtraColumns(names []string) ([]string, error) {
+	enabled := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+		case name == "all":
+			for _, c := range output.ResultColumns {
+				enabled[c.Key] = true
+			}
+		case output.FindResultColumn(name) != nil:
+			enabled[name] = true
+		default:
+			return nil, fmt.Errorf("unknown result column %q", name)
+		}
+	}
+
+	var keys []string
+	for _, c := range output.ResultColumns {
+		if enabled[c.Key] {
+			keys = append(keys, c.Key)
+		}
+	}
+	return keys, nil
+}
+
+// extraValue 计算一行在可选结果列中的值
+// 单元格中有多个仓库时，每个仓库一行，格式为 "owner/repo: 值"
+func extraValue(c *output.ResultColumn, row *RowResult, loc *time.Location) string {
+	if len(row.Repos) == 1 {
+		return c.Value(row.Repos[0].displayResult(row.Deadline), loc)
+	}
+
+	var lines []string
+	for _, repo := range row.Repos {
+		if value := c.Value(repo.displayResult(row.Deadline), loc); value != "" {
+			lines = append(lines, repo.Repository+": "+value)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,123 @@
+package batch
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+)
+
+func TestParseExtraColumns(t *testing.T) {
+	got, err := ParseExtraColumns([]string{"actor", " last_push ", "actor"})
+	if err != nil {
+		t.Fatalf("ParseExtraColumns failed: %v", err)
+	}
+	if strings.Join(got, ",") != "last_push,actor" {
+		t.Errorf("Expected columns in definition order, got %v", got)
+	}
+
+	all, err := ParseExtraColumns([]string{"all"})
+	if err != nil || len(all) != len(output.ResultColumns) {
+		t.Errorf("Expected all columns, got %v (%v)", all, err)
+	}
+
+	if _, err := ParseExtraColumns([]string{"stars"}); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+}
+
+func TestProcessor_ExtraColumns(t *testing.T) {
+	push := func(createdAt, actor, ref, head string) *models.UnifiedEvent {
+		return &models.UnifiedEvent{
+			BaseEvent:  models.BaseEvent{Type: "PushEvent", CreatedAt: createdAt},
+			ActorLogin: actor,
+			Payload:    map[string]interface{}{"ref": ref, "head": head},
+		}
+	}
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		missing:  map[string]bool{"team/private": true},
+		results: map[string]*models.AnalysisResult{
+			"team/late": {
+				Found:              true,
+				EventsChecked:      12,
+				LastCodeEvent:      push("2025-10-01T02:00:00Z", "alice", "refs/heads/main", "abc123"),
+				LastBeforeDeadline: push("2025-09-30T10:00:00Z", "bob", "refs/heads/dev", "def456"),
+				LatePushes:         2,
+				SubmittedBefore:    boolPtr(false),
+				TimeDifference:     "10 hours after deadline",
+			},
+		},
+	}
+
+	records := [][]string{
+		{"姓名", "代码仓库地址", "推送者"},
+		{"A", "https://github.com/team/late", ""},
+		{"B", "https://github.com/team/private", "keep?"},
+		{"C", "not a repository", "keep"},
+	}
+
+	mapping := DefaultColumnMapping()
+	mapping.Extra = []string{"all"}
+	p := NewProcessor(Options{
+		Deadline:  "2025-09-30T23:59:59+08:00",
+		Columns:   mapping,
+		Location:  time.FixedZone("CST", 8*3600),
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	if _, err := p.Process(context.Background(), records); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	header := records[0]
+	value := func(row int, column string) string {
+		index := findExactColumn(header, column)
+		if index == -1 {
+			t.Fatalf("Column %s not found in %v", column, header)
+		}
+		return records[row][index]
+	}
+
+	// 已有的"推送者"列被复用，其他列追加在结果列之后
+	if len(header) != 2+1+2+len(output.ResultColumns)-1 || findExactColumn(header, "推送者") != 2 {
+		t.Fatalf("Unexpected header: %v", header)
+	}
+
+	expected := map[string]string{
+		"截止前最后推送":  "2025-09-30 18:00:00 CST (2025-09-30 10:00:00 UTC)",
+		"最后推送时间":   "2025-10-01 10:00:00 CST (2025-10-01 02:00:00 UTC)",
+		"推送者":      "alice",
+		"推送分支":     "main",
+		"HEAD SHA": "abc123",
+		"截止后推送次数":  "2",
+		"时间差":      "10 hours after deadline",
+		"检查事件数":    "12",
+		"结论":       "late (high)",
+		"依据":       "push_event 2025-10-01 10:00:00 CST (2025-10-01 02:00:00 UTC)\npush_event 2025-09-30 18:00:00 CST (2025-09-30 10:00:00 UTC)",
+		"错误类型":     "",
+	}
+	for column, want := range expected {
+		if got := value(1, column); got != want {
+			t.Errorf("Row 2 %s: expected %q, got %q", column, want, got)
+		}
+	}
+
+	if got := value(2, "错误类型"); got != ErrorNotFound {
+		t.Errorf("Row 3: expected error category %q, got %q", ErrorNotFound, got)
+	}
+	if got := value(2, "结论"); got != "inaccessible (high)" {
+		t.Errorf("Row 3: expected inaccessible verdict, got %q", got)
+	}
+	if got := value(2, "推送者"); got != "" {
+		t.Errorf("Row 3: expected actor to be cleared, got %q", got)
+	}
+	if got := value(3, "推送者"); got != "keep" {
+		t.Errorf("Row 4: expected skipped row to be left unchanged, got %q", got)
+	}
+}
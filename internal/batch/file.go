@@ -0,0 +1,403 @@
+package batch
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+)
+
+// ReadFile 读取文件内容，支持CSV和Excel格式（Excel 读取第一个工作表）
+// 返回二维字符串数组，第一行为表头，后续为数据行
+func ReadFile(filename string) ([][]string, error) {
+	sheets, err := ReadSheets(filename, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return sheets[0].Records, nil
+}
+
+// Sheet 待处理的表格（Excel 工作表或 CSV 文件）
+type Sheet struct {
+	// Name 工作表名称，CSV 文件为空
+	Name string
+	// Records 表格的全部行，包括表头之前的行
+	Records [][]string
+
+	// headerRow 表头所在行的索引，resultColumns 结果列索引（写回 Excel 时只更新这些列），
+	// statusColumns 需要按状态着色的列索引
+	headerRow     int
+	resultColumns []int
+	statusColumns []int
+}
+
+// ReadSheets 读取要处理的表格
+// CSV 文件只有一个表格；Excel 文件按 sheet（名称或从1开始的序号）或 pattern（名称正则）选择工作表，
+// 都为空时读取第一个工作表
+func ReadSheets(filename, sheet, pattern string) ([]*Sheet, error) {
+	if sheet != "" && pattern != "" {
+		return nil, fmt.Errorf("不能同时指定工作表和工作表匹配规则")
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch ext {
+	case ".csv":
+		if sheet != "" || pattern != "" {
+			return nil, fmt.Errorf("CSV文件不支持选择工作表")
+		}
+		records, err := readCSVFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		return []*Sheet{{Records: records}}, nil
+	case ".xlsx", ".xls":
+		return readExcelSheets(filename, sheet, pattern)
+	default:
+		return nil, fmt.Errorf("不支持的文件格式: %s（支持.csv, .xlsx, .xls）", ext)
+	}
+}
+
+// readExcelSheets 读取 Excel 文件中选中的工作表
+func readExcelSheets(filename, sheet, pattern string) ([]*Sheet, error) {
+	file, err := excelize.OpenFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("无法打开Excel文件: %w", err)
+	}
+	defer file.Close()
+
+	names, err := selectSheets(file.GetSheetList(), sheet, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	sheets := make([]*Sheet, 0, len(names))
+	for _, name := range names {
+		rows, err := file.GetRows(name)
+		if err != nil {
+			return nil, fmt.Errorf("读取工作表 %s 失败: %w", name, err)
+		}
+		sheets = append(sheets, &Sheet{Name: name, Records: rows})
+	}
+	return sheets, nil
+}
+
+// selectSheets 按名称、序号或正则选择工作表
+func selectSheets(names []string, sheet, pattern string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("Excel文件中没有工作表")
+	}
+
+	switch {
+	case pattern != "":
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("无效的工作表匹配规则: %w", err)
+		}
+		var matched []string
+		for _, name := range names {
+			// 之前生成的仓库明细表不参与处理
+			if name != DetailsSheet && re.MatchString(name) {
+				matched = append(matched, name)
+			}
+		}
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("没有与 %q 匹配的工作表（现有工作表: %s）", pattern, strings.Join(names, ", "))
+		}
+		return matched, nil
+	case sheet != "":
+		for _, name := range names {
+			if name == sheet {
+				return []string{name}, nil
+			}
+		}
+		if index, err := strconv.Atoi(sheet); err == nil && index >= 1 && index <= len(names) {
+			return []string{names[index-1]}, nil
+		}
+		return nil, fmt.Errorf("未找到工作表 %q（现有工作表: %s）", sheet, strings.Join(names, ", "))
+	default:
+		return names[:1], nil
+	}
+}
+
+// readCSVFile 读取CSV文件
+func readCSVFile(filename string) ([][]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("无法打开CSV文件: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("读取CSV内容失败: %w", err)
+	}
+
+	return records, nil
+}
+
+// OutputPath 根据原文件名生成结果文件名
+func OutputPath(originalFilename string) string {
+	return outputPath(originalFilename, "_processed")
+}
+
+// ValidationPath 根据原文件名生成校验结果文件名，不会覆盖之前实际处理得到的结果文件
+func ValidationPath(originalFilename string) string {
+	return outputPath(originalFilename, "_validation")
+}
+
+// outputPath 在原文件名后加上 suffix，CSV 文件保持 .csv，其余为 .xlsx
+func outputPath(originalFilename, suffix string) string {
+	ext := strings.ToLower(filepath.Ext(originalFilename))
+	base := strings.TrimSuffix(originalFilename, filepath.Ext(originalFilename))
+	if ext == ".csv" {
+		return base + suffix + ".csv"
+	}
+	return base + suffix + ".xlsx"
+}
+
+// WriteFile 写入文件，根据原文件格式决定输出格式，返回结果文件路径
+// Excel 文件在原工作簿的副本上修改：只更新处理过的工作表中的结果列，
+// 其他工作表、单元格样式、列宽、数据验证和超链接保持不变
+func WriteFile(originalFilename string, sheets []*Sheet) (string, error) {
+	outputFile := OutputPath(originalFilename)
+	return outputFile, writeFile(originalFilename, outputFile, sheets)
+}
+
+// WriteValidationFile 把校验结果写入 ValidationPath 指定的文件，格式与 WriteFile 相同
+func WriteValidationFile(originalFilename string, sheets []*Sheet) (string, error) {
+	outputFile := ValidationPath(originalFilename)
+	return outputFile, writeFile(originalFilename, outputFile, sheets)
+}
+
+// writeFile 按原文件格式把各工作表写入 outputFile
+func writeFile(originalFilename, outputFile string, sheets []*Sheet) error {
+	switch ext := strings.ToLower(filepath.Ext(originalFilename)); ext {
+	case ".csv":
+		if len(sheets) != 1 {
+			return fmt.Errorf("CSV文件只能包含一个表格")
+		}
+		return writeCSVFile(outputFile, sheets[0].Records)
+	case ".xlsx", ".xls":
+		return writeExcelFile(originalFilename, outputFile, sheets)
+	default:
+		return fmt.Errorf("不支持的文件格式: %s", ext)
+	}
+}
+
+// writeCSVFile 写入CSV文件
+func writeCSVFile(outputFile string, records [][]string) error {
+	outFile, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("无法创建输出文件: %w", err)
+	}
+	defer outFile.Close()
+
+	writer := csv.NewWriter(outFile)
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("写入CSV失败: %w", err)
+	}
+
+	return nil
+}
+
+// writeExcelFile 把结果写入原工作簿的副本
+func writeExcelFile(originalFilename, outputFile string, sheets []*Sheet) error {
+	file, err := excelize.OpenFile(originalFilename)
+	if err != nil {
+		return fmt.Errorf("无法打开Excel文件: %w", err)
+	}
+	defer file.Close()
+
+	for _, sheet := range sheets {
+		name := sheet.Name
+		if name == "" {
+			name = file.GetSheetName(0)
+		}
+
+		if len(sheet.resultColumns) == 0 {
+			if err := setSheetRows(file, name, sheet.Records); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, col := range sheet.resultColumns {
+			if err := setSheetColumn(file, name, sheet.Records, sheet.headerRow, col); err != nil {
+				return err
+			}
+		}
+		if err := colorResultColumns(file, name, sheet.headerRow+2, len(sheet.Records), sheet.statusColumns); err != nil {
+			return err
+		}
+	}
+
+	if err := file.SaveAs(outputFile); err != nil {
+		return fmt.Errorf("保存Excel文件失败: %w", err)
+	}
+
+	return nil
+}
+
+// setSheetColumn 写入一列数据，保留单元格原有样式
+// header 为表头所在行的索引，新增的表头沿用左侧表头单元格的样式
+func setSheetColumn(file *excelize.File, sheetName string, records [][]string, header, col int) error {
+	for rowIndex, row := range records {
+		if col >= len(row) {
+			continue
+		}
+		cellName, err := excelize.CoordinatesToCellName(col+1, rowIndex+1)
+		if err != nil {
+			return fmt.Errorf("生成单元格坐标失败: %w", err)
+		}
+
+		current, err := file.GetCellValue(sheetName, cellName)
+		if err != nil {
+			return fmt.Errorf("读取单元格失败: %w", err)
+		}
+		if current == row[col] {
+			continue
+		}
+
+		if rowIndex == header && current == "" && col > 0 {
+			left, _ := excelize.CoordinatesToCellName(col, header+1)
+			if style, err := file.GetCellStyle(sheetName, left); err == nil && style != 0 {
+				if err := file.SetCellStyle(sheetName, cellName, cellName, style); err != nil {
+					return fmt.Errorf("设置单元格样式失败: %w", err)
+				}
+			}
+		}
+
+		if err := file.SetCellValue(sheetName, cellName, row[col]); err != nil {
+			return fmt.Errorf("设置单元格值失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// resultColors 结果单元格的条件格式颜色（背景色、字体色），按各语言的状态文字匹配
+var resultColors = []struct {
+	status string
+	fill   string
+	font   string
+}{
+	{StatusOnTime, "C6EFCE", "006100"},
+	{StatusLate, "FFC7CE", "9C0006"},
+	{StatusInaccessible, "D9D9D9", "595959"},
+	{StatusOnTimeMediumConfidence, "D8EDD0", "006100"},
+	{StatusLateMediumConfidence, "FDD5D5", "9C0006"},
+	{StatusOnTimeLowConfidence, "E2F0D9", "006100"},
+	{StatusLateLowConfidence, "FCE4D6", "9C0006"},
+}
+
+// colorResultColumns 为结果列 [firstRow, lastRow] 行（1-based）添加条件格式：
+// 准时提交为绿色，超时提交为红色，不可访问为灰色，可信度较低的结果使用较浅的颜色
+// 使用条件格式而不是直接修改单元格样式，原有样式保持不变
+func colorResultColumns(file *excelize.File, sheetName string, firstRow, lastRow int, resultColumns []int) error {
+	if lastRow < firstRow {
+		return nil
+	}
+
+	var formats []excelize.ConditionalFormatOptions
+	for _, c := range resultColors {
+		style, err := file.NewConditionalStyle(&excelize.Style{
+			Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{c.fill}},
+			Font: &excelize.Font{Color: c.font},
+		})
+		if err != nil {
+			return fmt.Errorf("创建条件格式失败: %w", err)
+		}
+		for _, lang := range i18n.Supported {
+			formats = append(formats, excelize.ConditionalFormatOptions{
+				Type:     "cell",
+				Criteria: "==",
+				Format:   &style,
+				Value:    fmt.Sprintf("%q", lang.T(i18n.StatusPrefix+c.status)),
+			})
+		}
+	}
+
+	for _, col := range resultColumns {
+		first, err := excelize.CoordinatesToCellName(col+1, firstRow)
+		if err != nil {
+			return fmt.Errorf("生成单元格坐标失败: %w", err)
+		}
+		last, err := excelize.CoordinatesToCellName(col+1, lastRow)
+		if err != nil {
+			return fmt.Errorf("生成单元格坐标失败: %w", err)
+		}
+		if err := file.SetConditionalFormat(sheetName, first+":"+last, formats); err != nil {
+			return fmt.Errorf("设置条件格式失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// setSheetRows 把所有行数据写入工作表
+func setSheetRows(file *excelize.File, sheetName string, records [][]string) error {
+	for rowIndex, row := range records {
+		for colIndex, cellValue := range row {
+			// Excel使用1-based索引
+			cellName, err := excelize.CoordinatesToCellName(colIndex+1, rowIndex+1)
+			if err != nil {
+				return fmt.Errorf("生成单元格坐标失败: %w", err)
+			}
+
+			if err := file.SetCellValue(sheetName, cellName, cellValue); err != nil {
+				return fmt.Errorf("设置单元格值失败: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteDetails 写入仓库明细，返回明细的保存位置
+// Excel 文件在结果文件中新增"仓库明细"工作表；CSV 文件写入单独的 <原文件名>_processed_repos.csv
+func WriteDetails(originalFilename, outputFile string, records [][]string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(originalFilename))
+
+	switch ext {
+	case ".csv":
+		detailsFile := strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + "_repos.csv"
+		return detailsFile, writeCSVFile(detailsFile, records)
+	case ".xlsx", ".xls":
+		return outputFile + "#" + DetailsSheet, addExcelSheet(outputFile, DetailsSheet, records)
+	default:
+		return "", fmt.Errorf("不支持的文件格式: %s", ext)
+	}
+}
+
+// addExcelSheet 在已有的 Excel 文件中添加（或覆盖）一个工作表
+func addExcelSheet(filename, sheetName string, records [][]string) error {
+	file, err := excelize.OpenFile(filename)
+	if err != nil {
+		return fmt.Errorf("无法打开Excel文件: %w", err)
+	}
+	defer file.Close()
+
+	if index, _ := file.GetSheetIndex(sheetName); index != -1 {
+		if err := file.DeleteSheet(sheetName); err != nil {
+			return fmt.Errorf("删除工作表失败: %w", err)
+		}
+	}
+	if _, err := file.NewSheet(sheetName); err != nil {
+		return fmt.Errorf("创建工作表失败: %w", err)
+	}
+	if err := setSheetRows(file, sheetName, records); err != nil {
+		return err
+	}
+	if err := colorResultColumns(file, sheetName, 2, len(records), detailStatusColumns(records[0])); err != nil {
+		return err
+	}
+
+	if err := file.Save(); err != nil {
+		return fmt.Errorf("保存Excel文件失败: %w", err)
+	}
+	return nil
+}
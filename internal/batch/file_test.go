@@ -0,0 +1,242 @@
+package batch
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// createWorkbook 创建带格式、第二个工作表、超链接和数据验证的报名表
+func createWorkbook(t *testing.T, path string) {
+	t.Helper()
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	rows := [][]any{
+		{"姓名", "代码仓库地址", "备注"},
+		{"A", "https://github.com/team/ontime", "x"},
+		{"B", "https://github.com/team/late", "y"},
+		{"C", "https://github.com/team/private", "z"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatalf("SetSheetRow failed: %v", err)
+		}
+	}
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		t.Fatalf("NewStyle failed: %v", err)
+	}
+	if err := f.SetCellStyle("Sheet1", "A1", "C1", bold); err != nil {
+		t.Fatalf("SetCellStyle failed: %v", err)
+	}
+	if err := f.SetColWidth("Sheet1", "B", "B", 42); err != nil {
+		t.Fatalf("SetColWidth failed: %v", err)
+	}
+	if err := f.SetCellHyperLink("Sheet1", "B2", "https://github.com/team/ontime", "External"); err != nil {
+		t.Fatalf("SetCellHyperLink failed: %v", err)
+	}
+	dv := excelize.NewDataValidation(true)
+	dv.Sqref = "C2:C4"
+	if err := dv.SetDropList([]string{"x", "y", "z"}); err != nil {
+		t.Fatalf("SetDropList failed: %v", err)
+	}
+	if err := f.AddDataValidation("Sheet1", dv); err != nil {
+		t.Fatalf("AddDataValidation failed: %v", err)
+	}
+
+	if _, err := f.NewSheet("说明"); err != nil {
+		t.Fatalf("NewSheet failed: %v", err)
+	}
+	if err := f.SetCellValue("说明", "A1", "请填写仓库地址"); err != nil {
+		t.Fatalf("SetCellValue failed: %v", err)
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("SaveAs failed: %v", err)
+	}
+}
+
+func TestRun_PreservesExcelWorkbook(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "sheet.xlsx")
+	createWorkbook(t, input)
+
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		missing:  map[string]bool{"team/private": true},
+		results: map[string]*models.AnalysisResult{
+			"team/ontime": {Found: true, SubmittedBefore: boolPtr(true)},
+			"team/late":   {Found: true, SubmittedBefore: boolPtr(false)},
+		},
+	}
+	summary, err := Run(context.Background(), input, Options{
+		Deadline:  "2025-09-30T23:59:59+08:00",
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	f, err := excelize.OpenFile(summary.OutputFile)
+	if err != nil {
+		t.Fatalf("Failed to open output: %v", err)
+	}
+	defer f.Close()
+
+	if sheets := f.GetSheetList(); len(sheets) != 2 || sheets[1] != "说明" {
+		t.Errorf("Expected other sheets to be kept, got %v", sheets)
+	}
+
+	expected := map[string]string{
+		"D1": ColumnAccess, "E1": ColumnSubmission,
+		"D2": StatusText(StatusAccessible), "E2": StatusText(StatusOnTime),
+		"D3": StatusText(StatusAccessible), "E3": StatusText(StatusLate),
+		"D4": StatusText(StatusInaccessible), "E4": "",
+		"C2": "x",
+	}
+	for cell, want := range expected {
+		if got, _ := f.GetCellValue("Sheet1", cell); got != want {
+			t.Errorf("%s: expected %q, got %q", cell, want, got)
+		}
+	}
+
+	if width, _ := f.GetColWidth("Sheet1", "B"); width != 42 {
+		t.Errorf("Expected column width to be kept, got %v", width)
+	}
+	if ok, link, _ := f.GetCellHyperLink("Sheet1", "B2"); !ok || link != "https://github.com/team/ontime" {
+		t.Errorf("Expected hyperlink to be kept, got %v %q", ok, link)
+	}
+	if dvs, _ := f.GetDataValidations("Sheet1"); len(dvs) != 1 {
+		t.Errorf("Expected data validation to be kept, got %d", len(dvs))
+	}
+
+	headerStyle, _ := f.GetCellStyle("Sheet1", "A1")
+	for _, cell := range []string{"C1", "D1", "E1"} {
+		if style, _ := f.GetCellStyle("Sheet1", cell); style != headerStyle {
+			t.Errorf("%s: expected header style %d, got %d", cell, headerStyle, style)
+		}
+	}
+
+	formats, err := f.GetConditionalFormats("Sheet1")
+	if err != nil {
+		t.Fatalf("GetConditionalFormats failed: %v", err)
+	}
+	for _, ref := range []string{"D2:D4", "E2:E4"} {
+		if want := len(resultColors) * len(i18n.Supported); len(formats[ref]) != want {
+			t.Errorf("Expected %d conditional formats on %s, got %v", want, ref, formats[ref])
+		}
+	}
+}
+
+func TestSelectSheets(t *testing.T) {
+	names := []string{"说明", "赛道一", "赛道二", DetailsSheet}
+
+	tests := []struct {
+		sheet, pattern string
+		want           []string
+		wantErr        bool
+	}{
+		{"", "", []string{"说明"}, false},
+		{"赛道二", "", []string{"赛道二"}, false},
+		{"2", "", []string{"赛道一"}, false},
+		{"5", "", nil, true},
+		{"", "^赛道", []string{"赛道一", "赛道二"}, false},
+		{"", ".*", []string{"说明", "赛道一", "赛道二"}, false},
+		{"", "^决赛", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := selectSheets(names, tt.sheet, tt.pattern)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("selectSheets(%q, %q) error = %v, wantErr %v", tt.sheet, tt.pattern, err, tt.wantErr)
+			continue
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("selectSheets(%q, %q) = %v, want %v", tt.sheet, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestRun_SheetPatternAndHeaderRow(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "tracks.xlsx")
+
+	f := excelize.NewFile()
+	for _, name := range []string{"赛道一", "赛道二"} {
+		if _, err := f.NewSheet(name); err != nil {
+			t.Fatalf("NewSheet failed: %v", err)
+		}
+		rows := [][]any{
+			{"2025 开源大赛报名表（" + name + "）"},
+			{},
+			{"姓名", "代码仓库地址"},
+			{"A", "https://github.com/team/ontime"},
+			{"B", "https://github.com/team/late"},
+		}
+		for i, row := range rows {
+			cell, _ := excelize.CoordinatesToCellName(1, i+1)
+			if err := f.SetSheetRow(name, cell, &row); err != nil {
+				t.Fatalf("SetSheetRow failed: %v", err)
+			}
+		}
+	}
+	if err := f.SaveAs(input); err != nil {
+		t.Fatalf("SaveAs failed: %v", err)
+	}
+	f.Close()
+
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		results: map[string]*models.AnalysisResult{
+			"team/ontime": {Found: true, SubmittedBefore: boolPtr(true)},
+			"team/late":   {Found: true, SubmittedBefore: boolPtr(false)},
+		},
+	}
+	summary, err := Run(context.Background(), input, Options{
+		Deadline:     "2025-09-30T23:59:59+08:00",
+		SheetPattern: "^赛道",
+		HeaderRow:    3,
+		NewClient:    func(models.Platform) (api.Client, error) { return client, nil },
+		Log:          io.Discard,
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if summary.Processed != 4 {
+		t.Errorf("Expected 4 processed rows, got %d", summary.Processed)
+	}
+	if row := summary.Rows[2]; row.Sheet != "赛道二" || row.Row != 4 {
+		t.Errorf("Expected row 4 of 赛道二, got %s row %d", row.Sheet, row.Row)
+	}
+
+	out, err := excelize.OpenFile(summary.OutputFile)
+	if err != nil {
+		t.Fatalf("Failed to open output: %v", err)
+	}
+	defer out.Close()
+
+	for _, name := range []string{"赛道一", "赛道二"} {
+		expected := map[string]string{
+			"A1": "2025 开源大赛报名表（" + name + "）",
+			"C1": "",
+			"C3": ColumnAccess, "D3": ColumnSubmission,
+			"D4": StatusText(StatusOnTime), "D5": StatusText(StatusLate),
+		}
+		for cell, want := range expected {
+			if got, _ := out.GetCellValue(name, cell); got != want {
+				t.Errorf("%s!%s: expected %q, got %q", name, cell, want, got)
+			}
+		}
+	}
+}
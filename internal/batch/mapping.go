@@ -0,0 +1,220 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 列选择器的前缀写法
+const (
+	selectorRegexPrefix  = "re:"
+	selectorLetterPrefix = "col:"
+)
+
+// ColumnSelector 指定表格中的一列
+// 三种方式任选其一：表头名称（包含匹配）、表头正则、列字母（A、B、…、AA）
+type ColumnSelector struct {
+	Header  string `json:"header,omitempty" yaml:"header,omitempty"`
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Letter  string `json:"letter,omitempty" yaml:"letter,omitempty"`
+}
+
+// ParseColumnSelector 解析列选择器的简写形式
+// "re:^仓库" 表示表头正则，"col:C" 表示列字母，其余按表头名称匹配
+func ParseColumnSelector(s string) (ColumnSelector, error) {
+	s = strings.TrimSpace(s)
+	var sel ColumnSelector
+	switch {
+	case strings.HasPrefix(s, selectorRegexPrefix):
+		sel.Pattern = strings.TrimPrefix(s, selectorRegexPrefix)
+	case strings.HasPrefix(s, selectorLetterPrefix):
+		sel.Letter = strings.TrimPrefix(s, selectorLetterPrefix)
+	default:
+		sel.Header = s
+	}
+	return sel, sel.validate()
+}
+
+// IsZero 判断是否未指定任何列
+func (s ColumnSelector) IsZero() bool {
+	return s.Header == "" && s.Pattern == "" && s.Letter == ""
+}
+
+// String 返回选择器的简写形式
+func (s ColumnSelector) String() string {
+	switch {
+	case s.Pattern != "":
+		return selectorRegexPrefix + s.Pattern
+	case s.Letter != "":
+		return selectorLetterPrefix + s.Letter
+	default:
+		return s.Header
+	}
+}
+
+// UnmarshalJSON 同时支持字符串简写和对象写法
+func (s *ColumnSelector) UnmarshalJSON(data []byte) error {
+	var short string
+	if err := json.Unmarshal(data, &short); err == nil {
+		parsed, err := ParseColumnSelector(short)
+		if err != nil {
+			return err
+		}
+		*s = parsed
+		return nil
+	}
+
+	// 对象写法替换整个选择器，而不是与默认值合并
+	*s = ColumnSelector{}
+	type plain ColumnSelector
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return s.validate()
+}
+
+// UnmarshalYAML 同时支持字符串简写和对象写法
+func (s *ColumnSelector) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		parsed, err := ParseColumnSelector(node.Value)
+		if err != nil {
+			return err
+		}
+		*s = parsed
+		return nil
+	}
+
+	*s = ColumnSelector{}
+	type plain ColumnSelector
+	if err := node.Decode((*plain)(s)); err != nil {
+		return err
+	}
+	return s.validate()
+}
+
+// validate 检查选择器是否合法
+func (s ColumnSelector) validate() error {
+	set := 0
+	for _, v := range []string{s.Header, s.Pattern, s.Letter} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return fmt.Errorf("列选择器只能指定 header、pattern、letter 中的一个: %+v", s)
+	}
+	if s.Pattern != "" {
+		if _, err := regexp.Compile(s.Pattern); err != nil {
+			return fmt.Errorf("无效的列正则 %q: %w", s.Pattern, err)
+		}
+	}
+	if s.Letter != "" {
+		if _, err := columnLetterIndex(s.Letter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// find 在表头中查找列，找不到时返回 -1
+// 列字母指向表头范围之外的列时同样返回 -1
+func (s ColumnSelector) find(headers []string) int {
+	switch {
+	case s.Letter != "":
+		index, _ := columnLetterIndex(s.Letter)
+		if index < len(headers) {
+			return index
+		}
+	case s.Pattern != "":
+		re := regexp.MustCompile(s.Pattern)
+		for i, header := range headers {
+			if re.MatchString(header) {
+				return i
+			}
+		}
+	case s.Header != "":
+		return findColumnIndex(headers, s.Header)
+	}
+	return -1
+}
+
+// columnLetterIndex 把列字母（A、B、…、Z、AA…）转换为 0-based 索引
+func columnLetterIndex(letter string) (int, error) {
+	letter = strings.ToUpper(strings.TrimSpace(letter))
+	if letter == "" || len(letter) > 3 {
+		return 0, fmt.Errorf("无效的列字母: %q", letter)
+	}
+	index := 0
+	for _, r := range letter {
+		if r < 'A' || r > 'Z' {
+			return 0, fmt.Errorf("无效的列字母: %q", letter)
+		}
+		index = index*26 + int(r-'A') + 1
+	}
+	return index - 1, nil
+}
+
+// ColumnMapping 表格列映射
+// 仓库地址列必须存在；结果列不存在时追加；其余列未指定或找不到时忽略
+type ColumnMapping struct {
+	// Repository 仓库地址列
+	Repository ColumnSelector `json:"repository" yaml:"repository"`
+	// Participant 参赛者姓名列
+	Participant ColumnSelector `json:"participant" yaml:"participant"`
+	// Team 队伍名称列
+	Team ColumnSelector `json:"team" yaml:"team"`
+	// Access 结果列：是否可访问
+	Access ColumnSelector `json:"access" yaml:"access"`
+	// Submission 结果列：是否准时提交
+	Submission ColumnSelector `json:"submission" yaml:"submission"`
+	// Deadline 每行单独的截止时间，单元格为空时使用全局截止时间
+	Deadline ColumnSelector `json:"deadline" yaml:"deadline"`
+	// Platform 每行单独的平台（github/gitee），用于 owner/repo 这类不带域名的写法
+	Platform ColumnSelector `json:"platform" yaml:"platform"`
+	// Extra 启用的可选结果列（见 ExtraColumnKeys），"all" 表示全部启用
+	Extra []string `json:"extra,omitempty" yaml:"extra,omitempty"`
+}
+
+// DefaultColumnMapping 返回默认列映射（与报名表的默认表头一致）
+func DefaultColumnMapping() ColumnMapping {
+	return ColumnMapping{
+		Repository:  ColumnSelector{Header: ColumnRepository},
+		Participant: ColumnSelector{Header: ColumnName},
+		Access:      ColumnSelector{Header: ColumnAccess},
+		Submission:  ColumnSelector{Header: ColumnSubmission},
+	}
+}
+
+// LoadColumnMapping 读取列映射配置文件（.json 按 JSON 解析，其余按 YAML 解析）
+// 配置文件中未出现的列沿用默认映射
+func LoadColumnMapping(path string) (ColumnMapping, error) {
+	mapping := DefaultColumnMapping()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return mapping, fmt.Errorf("无法读取列映射配置: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &mapping)
+	} else {
+		err = yaml.Unmarshal(data, &mapping)
+	}
+	if err != nil {
+		return mapping, fmt.Errorf("列映射配置解析失败: %w", err)
+	}
+
+	if mapping.Repository.IsZero() {
+		return mapping, fmt.Errorf("列映射配置缺少 repository 列")
+	}
+	if mapping.Extra, err = ParseExtraColumns(mapping.Extra); err != nil {
+		return mapping, fmt.Errorf("列映射配置解析失败: %w", err)
+	}
+	return mapping, nil
+}
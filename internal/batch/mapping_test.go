@@ -0,0 +1,168 @@
+package batch
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestParseColumnSelector(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ColumnSelector
+		wantErr bool
+	}{
+		{"代码仓库地址", ColumnSelector{Header: "代码仓库地址"}, false},
+		{"re:^repo", ColumnSelector{Pattern: "^repo"}, false},
+		{"col:c", ColumnSelector{Letter: "c"}, false},
+		{"re:(", ColumnSelector{}, true},
+		{"col:C1", ColumnSelector{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseColumnSelector(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseColumnSelector(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseColumnSelector(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestColumnLetterIndex(t *testing.T) {
+	tests := map[string]int{"A": 0, "b": 1, "Z": 25, "AA": 26, "AZ": 51, "BA": 52}
+	for letter, want := range tests {
+		got, err := columnLetterIndex(letter)
+		if err != nil || got != want {
+			t.Errorf("columnLetterIndex(%q) = %d, %v; want %d", letter, got, err, want)
+		}
+	}
+}
+
+func TestLoadColumnMapping(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"columns.yaml": "repository: col:D\nparticipant: \"re:^参赛\"\nteam:\n  header: 队伍\n",
+		"columns.json": `{"repository": "col:D", "participant": {"pattern": "^参赛"}, "team": "队伍"}`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+
+		mapping, err := LoadColumnMapping(path)
+		if err != nil {
+			t.Fatalf("LoadColumnMapping(%s) failed: %v", name, err)
+		}
+		if mapping.Repository.Letter != "D" || mapping.Participant.Pattern != "^参赛" || mapping.Team.Header != "队伍" {
+			t.Errorf("%s: unexpected mapping %+v", name, mapping)
+		}
+		// 未配置的列沿用默认值
+		if mapping.Access.Header != ColumnAccess || mapping.Submission.Header != ColumnSubmission {
+			t.Errorf("%s: expected default result columns, got %+v", name, mapping)
+		}
+	}
+}
+
+func TestProcessor_CustomColumnMapping(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitee,
+		results: map[string]*models.AnalysisResult{
+			"team/ontime": {Found: true, SubmittedBefore: boolPtr(true)},
+			"team/late":   {Found: true, SubmittedBefore: boolPtr(false)},
+		},
+		deadlines: map[string]string{},
+	}
+
+	records := [][]string{
+		{"参赛者", "队伍名称", "截止", "平台", "Repo URL", "Status"},
+		{"A", "T1", "", "gitee", "team/ontime"},
+		{"B", "T2", "2025-10-07T23:59:59+08:00", "", "https://gitee.com/team/late"},
+		{"C", "T3", "", "github", "https://gitee.com/team/other"},
+	}
+
+	p := NewProcessor(Options{
+		Deadline: "2025-09-30T23:59:59+08:00",
+		Columns: ColumnMapping{
+			Repository:  ColumnSelector{Letter: "E"},
+			Participant: ColumnSelector{Pattern: "^参赛"},
+			Team:        ColumnSelector{Header: "队伍"},
+			Deadline:    ColumnSelector{Header: "截止"},
+			Platform:    ColumnSelector{Header: "平台"},
+			Access:      ColumnSelector{Header: "Status"},
+			Submission:  ColumnSelector{Letter: "H"},
+		},
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	if got := records[0]; len(got) != 8 || got[6] != "" || got[7] != ColumnSubmission {
+		t.Fatalf("Expected submission column at H, got %v", got)
+	}
+	if records[1][5] != StatusText(StatusAccessible) || records[1][7] != StatusText(StatusOnTime) {
+		t.Errorf("Row 2: unexpected result %v", records[1])
+	}
+	if records[2][5] != StatusText(StatusAccessible) || records[2][7] != StatusText(StatusLate) {
+		t.Errorf("Row 3: unexpected result %v", records[2])
+	}
+	if records[3][5] != "" || records[3][7] != "" {
+		t.Errorf("Row 4: expected platform mismatch to be skipped, got %v", records[3])
+	}
+
+	if got := client.deadlines["team/ontime"]; got != "2025-09-30T23:59:59+08:00" {
+		t.Errorf("Expected global deadline for row 2, got %s", got)
+	}
+	if got := client.deadlines["team/late"]; got != "2025-10-07T23:59:59+08:00" {
+		t.Errorf("Expected per-row deadline for row 3, got %s", got)
+	}
+	if row := summary.Rows[0]; row.Name != "A" || row.Team != "T1" {
+		t.Errorf("Expected participant and team to be recorded, got %+v", row)
+	}
+}
+
+func TestProcessor_LocalDeadlineCell(t *testing.T) {
+	client := &fakeClient{
+		platform:  models.PlatformGitHub,
+		deadlines: map[string]string{},
+	}
+
+	records := [][]string{
+		{"姓名", "代码仓库地址", "截止时间"},
+		{"A", "https://github.com/team/local", "2025-09-30 23:59"},
+		{"B", "https://github.com/team/bad", "next friday"},
+	}
+
+	mapping := DefaultColumnMapping()
+	mapping.Deadline = ColumnSelector{Header: "截止时间"}
+	p := NewProcessor(Options{
+		Columns:   mapping,
+		Location:  time.FixedZone("CST", 8*3600),
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	if _, err := p.Process(context.Background(), records); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	if got := client.deadlines["team/local"]; got != "2025-09-30T23:59:00+08:00" {
+		t.Errorf("Expected deadline cell read in the configured time zone, got %s", got)
+	}
+	if got := client.deadlines["team/bad"]; got != "next friday" {
+		t.Errorf("Expected unparsable deadline cell to be passed through, got %s", got)
+	}
+}
@@ -0,0 +1,174 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// 报告中每行的结论，除 models 中的结论外还有未检查和跳过
+const (
+	VerdictOnTime       = models.VerdictOnTime
+	VerdictLate         = models.VerdictLate
+	VerdictUnknown      = models.VerdictUnknown
+	VerdictEmpty        = models.VerdictEmpty
+	VerdictInaccessible = models.VerdictInaccessible
+	VerdictUnchecked    = "unchecked"
+	VerdictSkipped      = "skipped"
+)
+
+// 报告记录类型
+const (
+	reportTypeRow     = "row"
+	reportTypeShared  = "shared"
+	reportTypeSummary = "summary"
+)
+
+// ReportRow 报告中的一行：表格行号、解析出的仓库、完整分析结果以及错误分类
+// 每个仓库的依据见 RepoResult.Verdict，Confidence 为得出本行结论的仓库中最低的可信度
+type ReportRow struct {
+	Type       string `json:"type"`
+	Verdict    string `json:"verdict"`
+	Confidence string `json:"confidence,omitempty"`
+	*RowResult
+}
+
+// ReportShared 报告中的一组多行共用的仓库
+type ReportShared struct {
+	Type string `json:"type"`
+	SharedRepository
+}
+
+// ReportSummary 报告末尾的汇总记录
+type ReportSummary struct {
+	Type      string         `json:"type"`
+	Rows      int            `json:"rows"`
+	Processed int            `json:"processed"`
+	Skipped   int            `json:"skipped"`
+	Verdicts  map[string]int `json:"verdicts"`
+	// Flags 按仓库标记统计的仓库数
+	Flags  map[string]int `json:"flags"`
+	Shared int            `json:"shared"`
+}
+
+// report NDJSON 格式的处理报告
+// 每处理完一行写入一条 ReportRow，所有行处理完后写入 ReportShared，最后写入 ReportSummary
+type report struct {
+	file    *os.File
+	summary ReportSummary
+}
+
+// ReportPath 根据原文件名生成默认的报告文件名
+func ReportPath(originalFilename string) string {
+	return strings.TrimSuffix(originalFilename, filepath.Ext(originalFilename)) + "_report.ndjson"
+}
+
+// openReport 创建报告文件，已存在时覆盖
+func openReport(path string) (*report, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("无法创建报告文件: %w", err)
+	}
+	return &report{
+		file:    file,
+		summary: ReportSummary{Type: reportTypeSummary, Verdicts: make(map[string]int), Flags: make(map[string]int)},
+	}, nil
+}
+
+// append 写入一行处理结果并计入汇总
+// 只在 done 回调中调用，按行号顺序写入，不需要加锁
+func (r *report) append(row *RowResult) error {
+	verdict, confidence := rowVerdict(row)
+	r.summary.Rows++
+	r.summary.Verdicts[verdict]++
+	if row.Skipped {
+		r.summary.Skipped++
+	} else {
+		r.summary.Processed++
+	}
+	for _, repo := range row.Repos {
+		for _, flag := range repo.Flags {
+			r.summary.Flags[flag]++
+		}
+	}
+	return r.write(ReportRow{Type: reportTypeRow, Verdict: verdict, Confidence: confidence, RowResult: row})
+}
+
+// appendShared 写入一组多行共用的仓库
+func (r *report) appendShared(shared SharedRepository) error {
+	r.summary.Shared++
+	return r.write(ReportShared{Type: reportTypeShared, SharedRepository: shared})
+}
+
+// Close 写入汇总记录并关闭报告文件
+func (r *report) Close() error {
+	err := r.write(r.summary)
+	if closeErr := r.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// write 写入一条 JSON 记录
+func (r *report) write(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = r.file.Write(append(data, '\n'))
+	return err
+}
+
+// rowVerdict 根据单行各仓库的结论得出本行的结论和可信度
+// 任一仓库不可访问即为不可访问，任一仓库超时即为超时，所有仓库准时才算准时，其余情况取第一个非准时的结论
+func rowVerdict(row *RowResult) (verdict, confidence string) {
+	switch {
+	case row.Skipped:
+		return VerdictSkipped, ""
+	case row.Submission == StatusNoDeadline:
+		return VerdictUnchecked, ""
+	}
+
+	verdicts := make([]*models.Verdict, len(row.Repos))
+	for i, repo := range row.Repos {
+		verdicts[i] = repo.Verdict
+		if verdicts[i] == nil {
+			verdicts[i] = &models.Verdict{Status: VerdictUnknown}
+		}
+	}
+	for _, status := range []string{VerdictInaccessible, VerdictLate} {
+		for _, v := range verdicts {
+			if v.Status == status {
+				return status, lowestConfidence(verdicts, status)
+			}
+		}
+	}
+	for _, v := range verdicts {
+		if v.Status != VerdictOnTime {
+			return v.Status, v.Confidence
+		}
+	}
+	if len(verdicts) == 0 {
+		return VerdictUnknown, ""
+	}
+	return VerdictOnTime, lowestConfidence(verdicts, VerdictOnTime)
+}
+
+// confidenceRank 可信度从低到高
+var confidenceRank = []string{models.ConfidenceLow, models.ConfidenceMedium, models.ConfidenceHigh}
+
+// lowestConfidence 结论为 status 的仓库中最低的可信度
+func lowestConfidence(verdicts []*models.Verdict, status string) string {
+	for _, confidence := range confidenceRank {
+		for _, v := range verdicts {
+			if v.Status == status && v.Confidence == confidence {
+				return confidence
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package batch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestProcessor_Report(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		missing:  map[string]bool{"team/private": true},
+		results: map[string]*models.AnalysisResult{
+			"team/ontime": {Found: true, EventsChecked: 3, SubmittedBefore: boolPtr(true)},
+			"team/late":   {Found: true, SubmittedBefore: boolPtr(false)},
+		},
+	}
+
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "https://github.com/team/ontime"},
+		{"B", "https://github.com/team/late"},
+		{"C", "https://github.com/team/private"},
+		{"D", "https://github.com/team/empty"},
+		{"E", "not a repository"},
+	}
+
+	path := filepath.Join(t.TempDir(), "sheet_report.ndjson")
+	p := NewProcessor(Options{
+		Deadline:   "2025-09-30T23:59:59+08:00",
+		ReportPath: path,
+		NewClient:  func(models.Platform) (api.Client, error) { return client, nil },
+		Log:        io.Discard,
+	})
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if summary.ReportFile != path {
+		t.Errorf("Expected report file %s, got %s", path, summary.ReportFile)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open report: %v", err)
+	}
+	defer file.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	if len(lines) != 6 {
+		t.Fatalf("Expected 5 rows and a summary, got %d lines", len(lines))
+	}
+	decode := func(line []byte, v any) {
+		t.Helper()
+		if err := json.Unmarshal(line, v); err != nil {
+			t.Fatalf("Invalid report line %s: %v", line, err)
+		}
+	}
+
+	var first ReportRow
+	decode(lines[0], &first)
+	if first.Type != "row" || first.Row != 2 || first.Verdict != VerdictOnTime {
+		t.Errorf("Unexpected first row: %+v", first)
+	}
+	if len(first.Repos) != 1 || first.Repos[0].Repository != "team/ontime" || first.Repos[0].Result.EventsChecked != 3 {
+		t.Errorf("Expected parsed repository and analysis result, got %+v", first.Repos)
+	}
+
+	var third ReportRow
+	decode(lines[2], &third)
+	if third.Verdict != VerdictInaccessible || third.Repos[0].ErrorCategory != ErrorNotFound {
+		t.Errorf("Expected inaccessible row with not_found error, got %+v", third.Repos[0])
+	}
+
+	var total ReportSummary
+	decode(lines[5], &total)
+	want := map[string]int{
+		VerdictOnTime: 1, VerdictLate: 1, VerdictInaccessible: 1, VerdictEmpty: 1, VerdictSkipped: 1,
+	}
+	if total.Type != "summary" || total.Rows != 5 || total.Processed != 4 || total.Skipped != 1 {
+		t.Errorf("Unexpected summary: %+v", total)
+	}
+	for verdict, n := range want {
+		if total.Verdicts[verdict] != n {
+			t.Errorf("Expected %d %s rows, got %d", n, verdict, total.Verdicts[verdict])
+		}
+	}
+}
@@ -0,0 +1,512 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/config"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+	"github.com/luoliwoshang/git-event-monitor/internal/platform"
+	"github.com/luoliwoshang/git-event-monitor/internal/repourl"
+	"github.com/luoliwoshang/git-event-monitor/internal/webhook"
+)
+
+// processRow 检查单行所有仓库的可访问性和提交时间
+// 只生成处理结果，不修改表格，结果由 writeRow 写回
+func (p *Processor) processRow(ctx context.Context, log logger, rowNum int, record []string, cols columns) *RowResult {
+	row := &RowResult{
+		Row:      rowNum,
+		RepoURL:  record[cols.repo],
+		Name:     cell(record, cols.name),
+		Team:     cell(record, cols.team),
+		Deadline: p.opts.Deadline,
+	}
+	if deadline := cell(record, cols.deadline); deadline != "" {
+		row.Deadline = deadline
+	}
+	defer log.logf("\n")
+
+	log.logf("📦 Processing row %d: %s\n", rowNum, row.Name)
+	if row.Team != "" {
+		log.logf("   Team: %s\n", row.Team)
+	}
+	log.logf("   Repository: %s\n", row.RepoURL)
+
+	row.Repos = parseRowRepositories(log, row.RepoURL, cell(record, cols.platform))
+	if len(row.Repos) == 0 {
+		// 对于无法解析的URL（非GitHub/Gitee、格式错误等），
+		// 只输出日志，不更新行
+		log.logf("   ⏭️  Skipping: Cannot parse repository URL (unsupported platform or invalid format)\n")
+		row.Skipped = true
+		return row
+	}
+	if len(row.Repos) > 1 {
+		log.logf("   Found %d repositories\n", len(row.Repos))
+	}
+
+	rules := p.rowRules(log, row, cell(record, cols.deadline))
+	for _, repo := range row.Repos {
+		p.checkRepository(ctx, log, repo, rules)
+		if repo.Transient {
+			row.Transient = true
+		}
+	}
+	row.Access, row.Submission = aggregate(row.Repos)
+	row.Lateness = worstLateness(row.Repos)
+	if len(row.Repos) > 1 {
+		log.logf("   📋 Overall: %s / %s\n", StatusText(row.Access), StatusText(row.Submission))
+	}
+
+	return row
+}
+
+// rowRules 单行适用的检查规则
+type rowRules struct {
+	// deadline 截止时间（RFC3339，含宽限时间），为空时只检查可访问性
+	deadline string
+	// start 比赛开始时间，零值时不检查
+	start time.Time
+	// branches 计入提交的分支，为空时不限分支
+	branches []string
+	// policy 宽限时间和扣分档位
+	policy models.LatenessPolicy
+	// startCommits 标记开始前推送较多提交的阈值，为 0 时使用默认值
+	startCommits int
+}
+
+// rowRules 确定单行适用的规则
+// 指定了比赛配置时，先按仓库、再按姓名查找参赛者，使用其赛道（找不到时为默认赛道）的规则；
+// 命令行的截止时间、开始时间、宽限时间和扣分档位优先于赛道，截止时间列不为空时优先于两者
+func (p *Processor) rowRules(log logger, row *RowResult, deadlineCell string) rowRules {
+	rules := rowRules{deadline: p.opts.Deadline, start: p.opts.Start, policy: p.opts.Policy, startCommits: p.opts.CommitsBeforeStart}
+
+	if contest := p.opts.Contest; contest != nil {
+		var participant *config.Participant
+		for _, repo := range row.Repos {
+			if participant = contest.FindByRepository(repo.Platform, repo.Repository); participant != nil {
+				break
+			}
+		}
+		if participant == nil {
+			participant = contest.FindByName(row.Name)
+		}
+
+		if track := contest.TrackFor(participant); track != nil {
+			row.Track = track.Name
+			if rules.deadline == "" {
+				rules.deadline = track.FormatDeadline()
+			}
+			if rules.start.IsZero() {
+				rules.start = track.StartTime()
+			}
+			rules.branches = track.Branches
+			rules.policy = track.MergePolicy(p.opts.Policy, p.opts.GraceSet, p.opts.PenaltySet)
+			if rules.startCommits == 0 {
+				rules.startCommits = track.CommitsBeforeStart
+			}
+			log.logf("   Track: %s\n", track.Name)
+		} else {
+			log.logf("   ⚠️  Not listed in the contest and no default track\n")
+		}
+	}
+
+	// 截止时间列中不带时区的时间按 Location 解析，无法解析时原样保留，由分析结果给出错误
+	if deadlineCell != "" {
+		rules.deadline = deadlineCell
+		if normalized, err := monitor.NormalizeTime(deadlineCell, p.opts.Location); err == nil {
+			rules.deadline = normalized
+		}
+	}
+	row.Deadline = rules.deadline
+	return rules
+}
+
+// parseRowRepositories 从单元格中提取所有仓库（同一仓库只保留一次）
+// platformCell 为平台列的值，无法解析的部分只输出日志
+func parseRowRepositories(log logger, repoCell, platformCell string) []*RepoResult {
+	var repos []*RepoResult
+	seen := make(map[string]bool)
+
+	for _, candidate := range SplitRepositoryURLs(repoCell) {
+		parsed, err := parseRowRepository(candidate, platformCell)
+		if err != nil {
+			// "前端"、"后端" 这类说明文字不是地址，不需要提示
+			if strings.ContainsAny(candidate, "./") {
+				log.logf("   ⚠️  Ignoring %s: %v\n", candidate, err)
+			}
+			continue
+		}
+
+		key := repoKey(parsed.Platform, parsed.FullName())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		repos = append(repos, &RepoResult{
+			RepoURL:    candidate,
+			Platform:   parsed.Platform,
+			Repository: parsed.FullName(),
+		})
+	}
+
+	return repos
+}
+
+// checkRepository 检查单个仓库的可访问性和提交时间
+func (p *Processor) checkRepository(ctx context.Context, log logger, repo *RepoResult, rules rowRules) {
+	log.logf("   Platform: %s, Repository: %s\n", repo.Platform, repo.Repository)
+	deadline := rules.deadline
+	defer repo.evaluate(rules, webhook.EventsForRepo(p.opts.Webhooks, repo.Platform, repo.Repository))
+
+	client, err := p.client(repo.Platform)
+	if err != nil {
+		log.logf("   ❌ Internal error: %v\n", err)
+		repo.Submission = StatusAnalysisFailed
+		repo.setError(err, ErrorInternal)
+		return
+	}
+	token := p.opts.TokenFor(repo.Platform)
+
+	// 检查是否可访问
+	_, err = client.GetEvents(ctx, repo.Repository, token)
+	if err != nil {
+		log.logf("   ❌ Repository not accessible: %v\n", err)
+		// 不可访问时，准时提交列留空，不做任何更新
+		repo.Access = StatusInaccessible
+		repo.Transient = isTransient(err)
+		repo.setError(err, api.ErrorCategory(err))
+		return
+	}
+
+	log.logf("   ✅ Repository accessible\n")
+	repo.Access = StatusAccessible
+
+	if !p.opts.SkipRepoInfo {
+		p.lookupRepository(ctx, log, client, token, repo, rules.start)
+	}
+
+	// 既没有截止时间也没有开始时间时，跳过推送事件分析
+	if deadline == "" && rules.start.IsZero() {
+		log.logf("   ⏭️  No deadline specified, skipping submission check\n")
+		repo.Submission = StatusNoDeadline
+		return
+	}
+
+	// 检查是否准时提交
+	req := &models.AnalysisRequest{
+		Repository: repo.Repository,
+		Platform:   repo.Platform,
+		Token:      token,
+		Deadline:   deadline,
+		Branches:   rules.branches,
+	}
+	if !rules.start.IsZero() {
+		req.Start = rules.start.Format(time.RFC3339)
+	}
+	if deadline != p.opts.Deadline {
+		log.logf("   Deadline: %s\n", monitor.FormatDualTime(deadline, p.opts.Location))
+	}
+
+	result, err := client.AnalyzeCodeEvents(ctx, req)
+	repo.Result = result
+	if err == nil {
+		applyLateness(result, rules)
+		if event := result.LastCodeEvent; event != nil {
+			log.logf("   📤 Last push: %s\n", monitor.FormatDualTime(event.CreatedAt, p.opts.Location))
+		}
+		checkStart(log, repo, result, p.opts.Location, rules.startCommits)
+	}
+
+	switch {
+	case err != nil:
+		log.logf("   ❌ Analysis failed: %v\n", err)
+		repo.Submission = StatusAnalysisFailed
+		repo.Transient = true
+		repo.setError(err, api.ErrorCategory(err))
+	case deadline == "":
+		log.logf("   ⏭️  No deadline specified, skipping submission check\n")
+		repo.Submission = StatusNoDeadline
+	case !result.Found:
+		// 没有找到PushEvent，需要进一步检查各分支的提交记录
+		log.logf("   ⚠️  No push events found in recent activity\n")
+		p.checkBranchCommits(ctx, log, client, token, repo, rules)
+	case result.SubmittedBefore == nil:
+		log.logf("   ⚠️  Could not determine submission time\n")
+		repo.Submission = StatusUndetermined
+		repo.Error, repo.ErrorCategory = result.Error, ErrorInvalidTime
+	case *result.SubmittedBefore:
+		if result.Lateness != nil && result.Lateness.Tier == models.TierGrace {
+			log.logf("   ✅ Submitted within the grace period (%s)\n", result.TimeDifference)
+		} else {
+			log.logf("   ✅ Submitted before deadline (%s)\n", result.TimeDifference)
+		}
+		repo.Submission = StatusOnTime
+	default:
+		log.logf("   ❌ Submitted after deadline (%s)\n", result.TimeDifference)
+		if result.Lateness != nil && len(rules.policy.Tiers) > 0 {
+			log.logf("   💸 Penalty tier %s: %s\n", result.Lateness.Tier, formatPenalty(result.Lateness.Penalty))
+		}
+		repo.Submission = StatusLate
+	}
+}
+
+// checkStart 输出最早的推送和开始前的推送，开始前推送的提交数达到 threshold 时加上标记
+func checkStart(log logger, repo *RepoResult, result *models.AnalysisResult, loc *time.Location, threshold int) {
+	if event := result.EarliestCodeEvent; event != nil {
+		log.logf("   🐣 Earliest push: %s\n", monitor.FormatDualTime(event.CreatedAt, loc))
+	}
+	if result.PushesBeforeStart > 0 {
+		log.logf("   ⚠️  %d pushes (%d commits) before the contest start\n", result.PushesBeforeStart, result.CommitsBeforeStart)
+	}
+	repo.Flags = append(repo.Flags, monitor.ActivityFlags(result, threshold)...)
+}
+
+// applyLateness 设置了宽限时间或扣分档位时，计算迟交档位和扣分
+func applyLateness(result *models.AnalysisResult, rules rowRules) {
+	if rules.policy.IsZero() {
+		return
+	}
+	if deadline, err := time.Parse(time.RFC3339, rules.deadline); err == nil {
+		monitor.ApplyLateness(result, deadline, rules.policy)
+	}
+}
+
+// worstLateness 各仓库中扣分最多的迟交档位，扣分相同时取迟交最久的
+func worstLateness(repos []*RepoResult) *models.Lateness {
+	var worst *models.Lateness
+	for _, repo := range repos {
+		if repo.Result == nil || repo.Result.Lateness == nil {
+			continue
+		}
+		lateness := repo.Result.Lateness
+		if worst == nil || lateness.Penalty > worst.Penalty ||
+			(lateness.Penalty == worst.Penalty && lateness.Seconds > worst.Seconds) {
+			worst = lateness
+		}
+	}
+	return worst
+}
+
+// formatPenalty 扣分的显示格式，整数不带小数
+func formatPenalty(penalty float64) string {
+	return strconv.FormatFloat(penalty, 'f', -1, 64)
+}
+
+// hasPenalties 是否设置了扣分档位（命令行或比赛配置）
+func (p *Processor) hasPenalties() bool {
+	return len(p.opts.Policy.Tiers) > 0 || (p.opts.Contest != nil && p.opts.Contest.HasPenalties())
+}
+
+// lookupRepository 查询仓库信息并给出标记，查询失败时只输出日志
+func (p *Processor) lookupRepository(ctx context.Context, log logger, client api.Client, token string, repo *RepoResult, start time.Time) {
+	info, err := client.GetRepository(ctx, repo.Repository, token)
+	if err != nil {
+		log.logf("   ⚠️  Failed to get repository info: %v\n", err)
+		return
+	}
+
+	repo.Info = info
+	repo.Flags = monitor.RepositoryFlags(info, start)
+	if info.Fork && info.Parent != "" {
+		log.logf("   🍴 Fork of %s\n", info.Parent)
+	}
+	if len(repo.Flags) > 0 {
+		log.logf("   🚩 Flags: %s\n", strings.Join(repo.Flags, ", "))
+	}
+}
+
+// evaluate 根据检查结果生成结构化的结论，并按结论更新准时提交列
+// 不可访问和空仓库直接给出结论，其余情况由 monitor.Evaluate 综合分析结果和仓库信息判断，
+// 再加入允许分支上的 Webhook 推送（webhooks 为该仓库的 Webhook 事件），两者都按 rules 的宽限时间判断
+func (r *RepoResult) evaluate(rules rowRules, webhooks []*models.UnifiedEvent) {
+	defer func() {
+		if r.Access != StatusInaccessible {
+			_, r.Submission = output.ResultStatus(r.displayResult(rules.deadline))
+		}
+	}()
+
+	switch {
+	case r.Access == StatusInaccessible:
+		r.Verdict = &models.Verdict{Status: models.VerdictInaccessible, Confidence: models.ConfidenceHigh}
+	case r.Submission == StatusEmptyRepo:
+		r.Verdict = &models.Verdict{Status: models.VerdictEmpty, Confidence: models.ConfidenceHigh}
+	default:
+		// 截止时间无法解析时只收集依据
+		deadlineTime, _ := time.Parse(time.RFC3339, rules.deadline)
+		r.Verdict = monitor.Evaluate(r.Result, r.Info, deadlineTime, rules.policy.Grace)
+		monitor.ApplyWebhookEvents(r.Verdict, webhooks, deadlineTime, rules.policy.Grace, rules.branches)
+	}
+}
+
+// lateStatuses 超时的状态值，可信度从高到低
+var lateStatuses = []string{StatusLate, StatusLateMediumConfidence, StatusLateLowConfidence}
+
+// onTimeStatuses 准时的状态值，可信度从高到低
+var onTimeStatuses = []string{StatusOnTime, StatusOnTimeMediumConfidence, StatusOnTimeLowConfidence}
+
+// aggregate 汇总多个仓库的检查结果
+// 任一仓库不可访问即为不可访问；任一仓库超时即为超时，取可信度最高的超时；所有仓库准时才算准时，取可信度最低的准时；
+// 有仓库不可访问且没有超时的仓库时准时提交列留空，其余情况取第一个非准时的状态
+func aggregate(repos []*RepoResult) (access, submission string) {
+	access = StatusAccessible
+	late := len(lateStatuses)
+	for _, repo := range repos {
+		if repo.Access != StatusAccessible && access != StatusInaccessible {
+			access = repo.Access
+		}
+		if i := statusIndex(lateStatuses, repo.Submission); i >= 0 && i < late {
+			late = i
+		}
+	}
+	if late < len(lateStatuses) {
+		return access, lateStatuses[late]
+	}
+	if access == StatusInaccessible {
+		return access, ""
+	}
+
+	onTime := 0
+	for _, repo := range repos {
+		i := statusIndex(onTimeStatuses, repo.Submission)
+		if i < 0 {
+			return access, repo.Submission
+		}
+		if i > onTime {
+			onTime = i
+		}
+	}
+	return access, onTimeStatuses[onTime]
+}
+
+// statusIndex 状态值在列表中的位置，不在列表中时返回 -1
+func statusIndex(statuses []string, status string) int {
+	for i, s := range statuses {
+		if s == status {
+			return i
+		}
+	}
+	return -1
+}
+
+// containsStatus 状态值是否在列表中
+func containsStatus(statuses []string, status string) bool {
+	return statusIndex(statuses, status) >= 0
+}
+
+// parseRowRepository 解析单行的仓库地址
+// platformCell 为平台列的值，非空时允许 owner/repo 的简写，并要求与 URL 中的平台一致
+func parseRowRepository(repoURL, platformCell string) (repourl.Repository, error) {
+	if platformCell == "" {
+		return repourl.Parse(repoURL)
+	}
+
+	explicit, err := platform.Parse(platformCell)
+	if err != nil {
+		return repourl.Repository{}, err
+	}
+	return repourl.ParseFor(repoURL, explicit)
+}
+
+// checkBranchCommits 没有推送事件时，按各分支截止时间前后最新提交的提交时间判断是否准时
+// 提交时间可以被伪造，结果标记为低可信度；查询失败或没有任何提交时，退回到只判断仓库是否为空
+func (p *Processor) checkBranchCommits(ctx context.Context, log logger, client api.Client, token string, repo *RepoResult, rules rowRules) {
+	deadlineTime, err := time.Parse(time.RFC3339, rules.deadline)
+	if err != nil {
+		p.checkCommits(ctx, log, client, token, repo)
+		return
+	}
+
+	branches, err := client.GetBranchCommits(ctx, repo.Repository, token, deadlineTime, monitor.CommitBranches(rules.branches, repo.Info))
+	if err != nil {
+		log.logf("   ⚠️  Failed to check branch commits: %v\n", err)
+		p.checkCommits(ctx, log, client, token, repo)
+		return
+	}
+	if !monitor.ApplyBranchCommits(repo.Result, branches, deadlineTime) {
+		p.checkCommits(ctx, log, client, token, repo)
+		return
+	}
+	applyLateness(repo.Result, rules)
+
+	hasCommits := true
+	repo.HasCommits = &hasCommits
+	repo.ErrorCategory = ErrorNoPushEvents
+	log.logf("   ℹ️  %s, judged by commit date (low confidence)\n", repo.Result.EventDescription)
+	if *repo.Result.SubmittedBefore {
+		log.logf("   ✅ Committed before deadline\n")
+		repo.Submission = StatusOnTimeLowConfidence
+	} else {
+		log.logf("   ❌ Committed after deadline\n")
+		repo.Submission = StatusLateLowConfidence
+	}
+}
+
+// checkCommits 没有推送事件时，通过提交记录判断仓库是否为空
+func (p *Processor) checkCommits(ctx context.Context, log logger, client api.Client, token string, row *RepoResult) {
+	hasCommits, err := client.HasCommits(ctx, row.Repository, token)
+	if err != nil {
+		// HasCommits API调用失败，记录为分析失败
+		log.logf("   ❌ Failed to check commits: %v\n", err)
+		row.Submission = StatusAnalysisFailed
+		row.Transient = true
+		row.setError(err, api.ErrorCategory(err))
+		return
+	}
+
+	row.HasCommits = &hasCommits
+	if hasCommits {
+		// 仓库有提交记录但没有PushEvent，可能是初始提交或批量提交
+		log.logf("   ℹ️  Repository has commits but no recent push events (likely initial commit)\n")
+		row.Submission = StatusInitialCommit
+		row.ErrorCategory = ErrorNoPushEvents
+	} else {
+		log.logf("   ⚠️  Repository is empty (no commits found)\n")
+		row.Submission = StatusEmptyRepo
+		row.ErrorCategory = ErrorEmptyRepository
+	}
+}
+
+// writeRow 把单行处理结果写回表格，空值对应的列保持原样
+// 可选结果列在跳过的行中保持原样，其余行按本次结果覆盖
+func writeRow(record []string, cols columns, row *RowResult, loc *time.Location) {
+	if row.Access != "" {
+		updateRecord(record, cols.access, StatusText(row.Access))
+	}
+	if row.Submission != "" {
+		updateRecord(record, cols.submission, StatusText(row.Submission))
+	}
+	if row.Skipped {
+		return
+	}
+	if row.Lateness != nil {
+		updateRecord(record, cols.penalty, formatPenalty(row.Lateness.Penalty))
+	} else {
+		updateRecord(record, cols.penalty, "")
+	}
+	for _, extra := range cols.extras {
+		updateRecord(record, extra.index, extraValue(extra.column, row, loc))
+	}
+}
+
+// setError 记录检查失败的原因和分类
+func (r *RepoResult) setError(err error, category string) {
+	r.Error = err.Error()
+	r.ErrorCategory = category
+}
+
+// isTransient 判断错误是否为临时性错误（网络错误、超时、服务端错误、限流）
+// 临时性错误的行在断点续跑时会重新处理
+func isTransient(err error) bool {
+	var statusErr *api.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Temporary()
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package batch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// 多行共用仓库的类型
+const (
+	// SharedDuplicate 两行填写了完全相同的 owner/repo
+	SharedDuplicate = "duplicate"
+	// SharedCaseDuplicate 两行的 owner/repo 只有大小写不同
+	SharedCaseDuplicate = "case_duplicate"
+	// SharedFork 一行的仓库是另一行仓库的 fork
+	SharedFork = "fork"
+)
+
+// RowRef 表格中的一行
+type RowRef struct {
+	Sheet string `json:"sheet,omitempty"`
+	Row   int    `json:"row"`
+}
+
+// String 返回 "工作表 第N行" 或 "第N行"
+func (r RowRef) String() string {
+	if r.Sheet != "" {
+		return fmt.Sprintf("%s 第%d行", r.Sheet, r.Row)
+	}
+	return fmt.Sprintf("第%d行", r.Row)
+}
+
+// SharedRepository 两行共用（或 fork 自）同一个仓库
+// 重复时 First 为先出现的行；fork 时 First 为上游仓库所在行，Second 为 fork 所在行
+type SharedRepository struct {
+	Kind     string          `json:"kind"`
+	Platform models.Platform `json:"platform"`
+	First    RowRef          `json:"first"`
+	Second   RowRef          `json:"second"`
+	// Repository 为 First 行的仓库，Other 为 Second 行的仓库
+	Repository string `json:"repository"`
+	Other      string `json:"other"`
+}
+
+// repoKey 仓库的比较键（平台 + 小写的 owner/repo）
+func repoKey(p models.Platform, fullName string) string {
+	return string(p) + ":" + strings.ToLower(fullName)
+}
+
+// FindSharedRepositories 在所有行之间查找重复填写的仓库，以及 fork 自其他行仓库的仓库
+// fork 关系来自仓库信息中的上游仓库；同一行内的重复在解析时已经去掉
+func FindSharedRepositories(rows []*RowResult) []SharedRepository {
+	type occurrence struct {
+		ref  RowRef
+		repo *RepoResult
+	}
+	index := make(map[string][]occurrence)
+	var shared []SharedRepository
+
+	for _, row := range rows {
+		if row.Skipped {
+			continue
+		}
+		ref := RowRef{Sheet: row.Sheet, Row: row.Row}
+		for _, repo := range row.Repos {
+			key := repoKey(repo.Platform, repo.Repository)
+			for _, earlier := range index[key] {
+				kind := SharedDuplicate
+				if earlier.repo.Repository != repo.Repository {
+					kind = SharedCaseDuplicate
+				}
+				shared = append(shared, SharedRepository{
+					Kind: kind, Platform: repo.Platform,
+					First: earlier.ref, Second: ref,
+					Repository: earlier.repo.Repository, Other: repo.Repository,
+				})
+			}
+			index[key] = append(index[key], occurrence{ref: ref, repo: repo})
+		}
+	}
+
+	for _, row := range rows {
+		ref := RowRef{Sheet: row.Sheet, Row: row.Row}
+		for _, repo := range row.Repos {
+			if repo.Info == nil || repo.Info.Parent == "" {
+				continue
+			}
+			for _, upstream := range index[repoKey(repo.Platform, repo.Info.Parent)] {
+				if upstream.ref == ref {
+					continue
+				}
+				shared = append(shared, SharedRepository{
+					Kind: SharedFork, Platform: repo.Platform,
+					First: upstream.ref, Second: ref,
+					Repository: upstream.repo.Repository, Other: repo.Repository,
+				})
+			}
+		}
+	}
+
+	return shared
+}
+
+// logShared 输出多行共用的仓库
+func (p *Processor) logShared(shared []SharedRepository) {
+	if len(shared) == 0 {
+		return
+	}
+
+	p.logf("🔁 Found %d repositories shared by several rows:\n", len(shared))
+	for _, s := range shared {
+		switch s.Kind {
+		case SharedFork:
+			p.logf("  [%s] %s %s ← %s %s\n", s.Kind, s.First, s.Repository, s.Second, s.Other)
+		default:
+			p.logf("  [%s] %s %s = %s %s\n", s.Kind, s.First, s.Repository, s.Second, s.Other)
+		}
+	}
+	p.logf("\n")
+}
@@ -0,0 +1,74 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestProcessor_SharedRepositories(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		parents:  map[string]string{"team-c/project": "Team-A/Project"},
+	}
+
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "https://github.com/team-a/project"},
+		{"B", "https://github.com/team-a/project.git"},
+		{"C", "https://github.com/team-c/project"},
+		{"D", "https://github.com/Team-A/Project https://github.com/team-d/other"},
+		{"E", "https://gitee.com/team-a/project"},
+	}
+
+	p := NewProcessor(Options{
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	var got []string
+	for _, s := range summary.Shared {
+		got = append(got, fmt.Sprintf("%s:%d-%d", s.Kind, s.First.Row, s.Second.Row))
+	}
+	want := []string{
+		"duplicate:2-3",
+		"case_duplicate:2-5",
+		"case_duplicate:3-5",
+		"fork:2-4",
+		"fork:3-4",
+		"fork:5-4",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Unexpected shared repositories:\n got %v\nwant %v", got, want)
+	}
+
+	fork := summary.Shared[3]
+	if fork.Repository != "team-a/project" || fork.Other != "team-c/project" {
+		t.Errorf("Expected fork to reference upstream and fork, got %+v", fork)
+	}
+}
+
+func TestFindSharedRepositories_WithoutForkDetection(t *testing.T) {
+	rows := []*RowResult{
+		{Sheet: "赛道一", Row: 2, Repos: []*RepoResult{{Platform: models.PlatformGitHub, Repository: "a/b"}}},
+		{Sheet: "赛道一", Row: 3, Skipped: true},
+		{Sheet: "赛道二", Row: 2, Repos: []*RepoResult{{Platform: models.PlatformGitHub, Repository: "a/b"}}},
+	}
+
+	shared := FindSharedRepositories(rows)
+	if len(shared) != 1 {
+		t.Fatalf("Expected 1 shared repository, got %+v", shared)
+	}
+	if shared[0].First.String() != "赛道一 第2行" || shared[0].Second.String() != "赛道二 第2行" {
+		t.Errorf("Unexpected rows: %s / %s", shared[0].First, shared[0].Second)
+	}
+}
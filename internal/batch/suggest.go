@@ -0,0 +1,78 @@
+package batch
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Layout 根据表格内容推测出的表头行和仓库地址列
+type Layout struct {
+	// HeaderRow 表头所在行（1-based）
+	HeaderRow int
+	// RepoColumn 仓库地址列的索引
+	RepoColumn int
+	// Header 仓库地址列的表头
+	Header string
+	// Repositories 该列中能解析出仓库地址的单元格数量
+	Repositories int
+}
+
+// ColumnLetter 返回仓库地址列的列字母
+func (l Layout) ColumnLetter() string {
+	name, _ := excelize.ColumnNumberToName(l.RepoColumn + 1)
+	return name
+}
+
+// Flags 返回与推测结果对应的 batch 命令参数
+func (l Layout) Flags() string {
+	return fmt.Sprintf("--header-row %d --repo-column %q", l.HeaderRow, selectorLetterPrefix+l.ColumnLetter())
+}
+
+// SuggestLayout 推测表头行和仓库地址列
+// 能解析出仓库地址最多的列为仓库地址列，该列第一个地址之上最近的非空单元格所在行为表头行；
+// 找不到仓库地址或地址之上没有表头时返回 false
+func SuggestLayout(rows [][]string) (Layout, bool) {
+	counts := make(map[int]int)
+	first := make(map[int]int)
+	for r, row := range rows {
+		for c, value := range row {
+			if !containsRepository(value) {
+				continue
+			}
+			if counts[c] == 0 {
+				first[c] = r
+			}
+			counts[c]++
+		}
+	}
+
+	layout := Layout{RepoColumn: -1}
+	for c, n := range counts {
+		if n > layout.Repositories || (n == layout.Repositories && c < layout.RepoColumn) {
+			layout.RepoColumn, layout.Repositories = c, n
+		}
+	}
+	if layout.RepoColumn == -1 {
+		return layout, false
+	}
+
+	for r := first[layout.RepoColumn] - 1; r >= 0; r-- {
+		if header := cell(rows[r], layout.RepoColumn); header != "" {
+			layout.HeaderRow = r + 1
+			layout.Header = header
+			return layout, true
+		}
+	}
+	return layout, false
+}
+
+// containsRepository 判断单元格中是否至少包含一个能解析的仓库地址
+func containsRepository(value string) bool {
+	for _, candidate := range SplitRepositoryURLs(value) {
+		if platform, _, _ := ParseRepositoryURL(candidate); platform != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package batch
+
+import "testing"
+
+func TestSuggestLayout(t *testing.T) {
+	rows := [][]string{
+		{"2025 开源大赛报名表"},
+		{},
+		{"序号", "姓名", "作品说明", "仓库"},
+		{"1", "A", "见 https://example.com/doc", "https://github.com/team/a"},
+		{"2", "B", "", "前端 https://gitee.com/team/b，后端 https://gitee.com/team/c"},
+		{"3", "C", "", "暂无"},
+		{"4", "D", "https://github.com/team/d", "https://github.com/team/d"},
+	}
+
+	layout, ok := SuggestLayout(rows)
+	if !ok {
+		t.Fatal("Expected a layout to be suggested")
+	}
+	if layout.HeaderRow != 3 || layout.RepoColumn != 3 || layout.Header != "仓库" || layout.Repositories != 3 {
+		t.Errorf("Unexpected layout: %+v", layout)
+	}
+	if got := layout.Flags(); got != `--header-row 3 --repo-column "col:D"` {
+		t.Errorf("Unexpected flags: %s", got)
+	}
+}
+
+func TestSuggestLayout_NoRepositories(t *testing.T) {
+	if _, ok := SuggestLayout([][]string{{"姓名"}, {"A"}}); ok {
+		t.Error("Expected no layout without repository URLs")
+	}
+	if _, ok := SuggestLayout([][]string{{"https://github.com/team/a"}}); ok {
+		t.Error("Expected no layout without a header above the repository URLs")
+	}
+}
@@ -0,0 +1,108 @@
+package batch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+)
+
+// summaryExtensions 汇总格式对应的文件扩展名
+var summaryExtensions = map[string]string{
+	"markdown": ".md",
+	"html":     ".html",
+	"template": ".txt",
+}
+
+// SummaryPath 根据原文件名生成默认的汇总文件名，如 <file>_summary.md
+func SummaryPath(originalFilename, format string) string {
+	return strings.TrimSuffix(originalFilename, filepath.Ext(originalFilename)) + "_summary" + summaryExtensions[format]
+}
+
+// SummaryEntries 把处理结果转换为汇总条目，每个仓库一条，跳过的行不列出
+func SummaryEntries(rows []*RowResult) []output.SummaryEntry {
+	var entries []output.SummaryEntry
+	for _, row := range rows {
+		entries = append(entries, rowEntries(row)...)
+	}
+	return entries
+}
+
+// rowEntries 一行中每个仓库的汇总条目，跳过的行没有条目
+func rowEntries(row *RowResult) []output.SummaryEntry {
+	if row.Skipped {
+		return nil
+	}
+	entries := make([]output.SummaryEntry, 0, len(row.Repos))
+	for _, repo := range row.Repos {
+		entries = append(entries, output.SummaryEntry{
+			Sheet:      row.Sheet,
+			Row:        row.Row,
+			Name:       row.Name,
+			Team:       row.Team,
+			Track:      row.Track,
+			Repository: repo.Repository,
+			URL:        repo.RepoURL,
+			Platform:   repo.Platform,
+			Result:     repo.displayResult(row.Deadline),
+		})
+	}
+	return entries
+}
+
+// WriteSummary 按 format（markdown、html 或 template）把汇总写入 path，时间按 loc 显示
+// format 为 template 时 tmpl 为模板文件或内置模板名称（见 output.NewTemplateFormatter）
+func WriteSummary(path, format, tmpl string, rows []*RowResult, loc *time.Location) error {
+	if _, ok := summaryExtensions[format]; !ok {
+		return fmt.Errorf("不支持的汇总格式: %s（支持 markdown、html、template）", format)
+	}
+	// 先创建格式化器，模板有误时不创建文件
+	var formatter output.Formatter
+	if format == "template" {
+		var err error
+		if formatter, err = output.NewTemplateFormatter(tmpl, loc); err != nil {
+			return err
+		}
+	} else {
+		formatter = output.NewFormatter(format, loc)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建汇总文件失败: %w", err)
+	}
+	defer file.Close()
+
+	if err := formatter.FormatSummary(file, SummaryEntries(rows)); err != nil {
+		return fmt.Errorf("写入汇总文件失败: %w", err)
+	}
+	return file.Close()
+}
+
+// displayResult 输出用的分析结果：附上保存在 RepoResult 中的结论、仓库信息、标记和错误分类
+// 没有分析结果（如仓库不可访问）时只包含结论，deadline 为本行的截止时间
+func (r *RepoResult) displayResult(deadline string) *models.AnalysisResult {
+	result := &models.AnalysisResult{}
+	if r.Result != nil {
+		copied := *r.Result
+		result = &copied
+	}
+	result.Verdict = r.Verdict
+	if r.Info != nil {
+		result.Repository = r.Info
+	}
+	if len(r.Flags) > 0 {
+		result.Flags = r.Flags
+	}
+	if r.ErrorCategory != "" {
+		result.ErrorCode = r.ErrorCategory
+	}
+	if result.Deadline == "" {
+		result.Deadline = deadline
+	}
+	return result
+}
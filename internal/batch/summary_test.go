@@ -0,0 +1,107 @@
+package batch
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+)
+
+func TestSummaryEntries(t *testing.T) {
+	client := &fakeClient{
+		platform: models.PlatformGitHub,
+		missing:  map[string]bool{"team/private": true},
+		results: map[string]*models.AnalysisResult{
+			"team/late": {Found: true, SubmittedBefore: boolPtr(false)},
+		},
+	}
+	records := [][]string{
+		{"姓名", "代码仓库地址"},
+		{"A", "https://github.com/team/late"},
+		{"B", "https://github.com/team/private"},
+		{"C", ""},
+	}
+
+	p := NewProcessor(Options{
+		Deadline:  "2025-09-30T23:59:59+08:00",
+		NewClient: func(models.Platform) (api.Client, error) { return client, nil },
+		Log:       io.Discard,
+	})
+	summary, err := p.Process(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	entries := SummaryEntries(summary.Rows)
+	if len(entries) != 2 {
+		t.Fatalf("Expected one entry per repository of the processed rows, got %+v", entries)
+	}
+	late := entries[0]
+	if late.Row != 2 || late.Name != "A" || late.Repository != "team/late" || late.URL != "https://github.com/team/late" {
+		t.Errorf("Unexpected entry: %+v", late)
+	}
+	if late.Result.Verdict == nil || late.Result.Verdict.Status != models.VerdictLate {
+		t.Errorf("Expected late verdict attached, got %+v", late.Result.Verdict)
+	}
+	if late.Result.Deadline == "" {
+		t.Error("Expected the row deadline on the result")
+	}
+	if entries[1].Result.Verdict.Status != models.VerdictInaccessible {
+		t.Errorf("Expected inaccessible verdict without an analysis result, got %+v", entries[1].Result)
+	}
+
+	path := filepath.Join(t.TempDir(), SummaryPath("sheet.xlsx", "html"))
+	if !strings.HasSuffix(path, "sheet_summary.html") {
+		t.Errorf("Unexpected summary path %s", path)
+	}
+	if err := WriteSummary(path, "html", "", summary.Rows, time.UTC); err != nil {
+		t.Fatalf("WriteSummary failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read summary: %v", err)
+	}
+	if !strings.Contains(string(data), `href="https://github.com/team/private"`) {
+		t.Errorf("Expected repository links in summary, got:\n%s", data)
+	}
+
+	if err := WriteSummary(path, "pdf", "", summary.Rows, time.UTC); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestDetailRecords_MatchOutputRecords(t *testing.T) {
+	// 状态为准时，结论为中可信度的准时
+	rows := []*RowResult{{
+		Row:      2,
+		Name:     "A",
+		Deadline: "2025-09-30T23:59:59+08:00",
+		Repos: []*RepoResult{
+			{Repository: "team/one", Platform: models.PlatformGitHub, Access: StatusAccessible, Submission: StatusOnTime,
+				Verdict: &models.Verdict{Status: models.VerdictOnTime, Confidence: models.ConfidenceMedium}},
+			{Repository: "team/gone", Platform: models.PlatformGitHub, Access: StatusInaccessible,
+				Verdict: &models.Verdict{Status: models.VerdictInaccessible, Confidence: models.ConfidenceHigh}},
+		},
+	}}
+
+	details := DetailRecords(rows, nil, time.UTC)
+	records := output.Records(SummaryEntries(rows), time.UTC)
+	if len(details) != len(records) {
+		t.Fatalf("Expected %d rows, got %d", len(records), len(details))
+	}
+	for i := 1; i < len(records); i++ {
+		if details[i][6] != records[i][6] || details[i][7] != records[i][7] {
+			t.Errorf("Row %d: details %v differ from records %v", i, details[i][6:8], records[i][6:8])
+		}
+	}
+	if details[1][7] != StatusText(StatusOnTimeMediumConfidence) {
+		t.Errorf("Expected the status to follow the verdict, got %q", details[1][7])
+	}
+}
@@ -0,0 +1,34 @@
+package batch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/repourl"
+)
+
+// urlSeparators 单元格中分隔多个仓库地址的字符（空白字符另行处理）
+const urlSeparators = ",;|\"'<>()[]，；、。｜：“”‘’（）【】《》"
+
+// ParseRepositoryURL 解析单个仓库 URL，返回平台、owner、repo
+// 支持的写法见 repourl.Parse；无法解析时返回空字符串，需要原因时直接使用 repourl.Parse
+// 单元格中包含多个地址时先用 SplitRepositoryURLs 拆分
+func ParseRepositoryURL(url string) (platform models.Platform, owner, repo string) {
+	r, err := repourl.Parse(url)
+	if err != nil {
+		return "", "", ""
+	}
+	return r.Platform, r.Owner, r.Name
+}
+
+// schemeReplacer 把全角的协议分隔符还原为 "://"，避免全角冒号被当作分隔符
+var schemeReplacer = strings.NewReplacer("：／／", "://", "：//", "://", ":／／", "://")
+
+// SplitRepositoryURLs 把单元格内容拆分为多个候选仓库地址
+// 按空白字符、逗号、分号以及中文标点拆分，并去除空项
+func SplitRepositoryURLs(cell string) []string {
+	return strings.FieldsFunc(schemeReplacer.Replace(cell), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(urlSeparators, r)
+	})
+}
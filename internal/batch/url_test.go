@@ -0,0 +1,29 @@
+package batch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitRepositoryURLs(t *testing.T) {
+	tests := []struct {
+		cell string
+		want []string
+	}{
+		{"https://github.com/a/b", []string{"https://github.com/a/b"}},
+		{"前端 https://github.com/a/web\n后端 https://github.com/a/api", []string{"前端", "https://github.com/a/web", "后端", "https://github.com/a/api"}},
+		{"https://github.com/a/web,https://gitee.com/a/api", []string{"https://github.com/a/web", "https://gitee.com/a/api"}},
+		{"https://github.com/a/web；https://github.com/a/api。", []string{"https://github.com/a/web", "https://github.com/a/api"}},
+		{"https://github.com/a/web、 https://github.com/a/api，", []string{"https://github.com/a/web", "https://github.com/a/api"}},
+		{"前端：https://github.com/a/web（主仓库）", []string{"前端", "https://github.com/a/web", "主仓库"}},
+		{"https：／／github.com／a／web", []string{"https://github.com／a／web"}},
+		{"  ", []string{}},
+	}
+
+	for _, tt := range tests {
+		got := SplitRepositoryURLs(tt.cell)
+		if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("SplitRepositoryURLs(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
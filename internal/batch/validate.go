@@ -0,0 +1,210 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/platform"
+	"github.com/luoliwoshang/git-event-monitor/internal/repourl"
+)
+
+// ColumnValidation 校验结果列
+const ColumnValidation = "校验结果"
+
+// 校验问题类型
+const (
+	IssueMissingRepository   = "missing_repository"
+	IssueInvalidURL          = "invalid_url"
+	IssueUnsupportedPlatform = "unsupported_platform"
+	IssueDuplicate           = "duplicate"
+	IssueMissingName         = "missing_name"
+)
+
+// ValidationIssue 单个校验问题
+type ValidationIssue struct {
+	Sheet   string `json:"sheet,omitempty"`
+	Row     int    `json:"row"`
+	Kind    string `json:"kind"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationReport 校验报告
+type ValidationReport struct {
+	// Rows 校验的行数，Repositories 解析出的仓库数（不含重复）
+	Rows         int               `json:"rows"`
+	Repositories int               `json:"repositories"`
+	Issues       []ValidationIssue `json:"issues,omitempty"`
+}
+
+// Counts 按问题类型统计数量
+func (r *ValidationReport) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range r.Issues {
+		counts[issue.Kind]++
+	}
+	return counts
+}
+
+// ValidateSheets 只做列识别和地址解析，不调用任何 API
+// 检查仓库地址为空、无法解析、平台不支持、仓库重复（跨工作表）以及姓名为空的行；
+// WriteValidation 为 true 时把每行的问题写入"校验结果"列
+func (p *Processor) ValidateSheets(sheets []*Sheet) (*ValidationReport, error) {
+	report := &ValidationReport{}
+	seen := make(map[string]RowRef)
+
+	for _, sheet := range sheets {
+		if err := p.validateSheet(sheet, seen, report); err != nil {
+			if sheet.Name != "" {
+				return nil, fmt.Errorf("工作表 %s: %w", sheet.Name, err)
+			}
+			return nil, err
+		}
+	}
+
+	p.logValidation(report)
+	return report, nil
+}
+
+// validateSheet 校验单个工作表，问题追加到 report
+func (p *Processor) validateSheet(sheet *Sheet, seen map[string]RowRef, report *ValidationReport) error {
+	records := sheet.Records
+	header := p.opts.HeaderRow - 1
+	if len(records) < header+2 {
+		return fmt.Errorf("文件至少需要包含表头（第%d行）和一行数据", header+1)
+	}
+
+	cols, err := p.findColumns(records[header])
+	if err != nil {
+		return err
+	}
+	startRow, endRow, err := p.rowRange(len(records), header)
+	if err != nil {
+		return err
+	}
+	if cols.name == -1 {
+		p.logf("⚠️  Column '%s' not found, names are not checked\n", p.opts.Columns.Participant)
+	}
+
+	result := -1
+	if p.opts.WriteValidation {
+		table := records[header:]
+		padRecords(table)
+		if result = findExactColumn(table[0], ColumnValidation); result == -1 {
+			result = p.addResultColumn(table, ColumnSelector{}, ColumnValidation)
+		}
+		sheet.headerRow = header
+		sheet.resultColumns = []int{result}
+	}
+
+	for i := startRow - 1; i < endRow; i++ {
+		record := records[i]
+		issues := validateRow(sheet.Name, i+1, record, cols, seen, &report.Repositories)
+		report.Rows++
+		report.Issues = append(report.Issues, issues...)
+
+		var messages []string
+		for _, issue := range issues {
+			messages = append(messages, issue.Message)
+		}
+		updateRecord(record, result, strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// validateRow 校验单行，repositories 累加新出现的仓库数
+func validateRow(sheet string, rowNum int, record []string, cols columns, seen map[string]RowRef, repositories *int) []ValidationIssue {
+	var issues []ValidationIssue
+	add := func(kind, value, format string, args ...any) {
+		issues = append(issues, ValidationIssue{
+			Sheet: sheet, Row: rowNum, Kind: kind, Value: value, Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if cols.name != -1 && cell(record, cols.name) == "" {
+		add(IssueMissingName, "", "姓名为空")
+	}
+
+	repoCell := cell(record, cols.repo)
+	if repoCell == "" {
+		add(IssueMissingRepository, "", "仓库地址为空")
+		return issues
+	}
+
+	platformCell := cell(record, cols.platform)
+	if platformCell != "" {
+		if _, err := platform.Parse(platformCell); err != nil {
+			add(IssueUnsupportedPlatform, platformCell, "不支持的平台: %s", platformCell)
+			return issues
+		}
+	}
+
+	found := false
+	for _, candidate := range SplitRepositoryURLs(repoCell) {
+		parsed, err := parseRowRepository(candidate, platformCell)
+		if err != nil {
+			// 与处理时一致，不含 "." 或 "/" 的部分视为说明文字
+			if !strings.ContainsAny(candidate, "./") {
+				continue
+			}
+			if errors.Is(err, repourl.ErrUnsupportedHost) && hasDomain(candidate) {
+				add(IssueUnsupportedPlatform, candidate, "不支持的平台: %s", candidate)
+			} else {
+				add(IssueInvalidURL, candidate, "无法解析: %v", err)
+			}
+			continue
+		}
+		found = true
+
+		key := repoKey(parsed.Platform, parsed.FullName())
+		first, ok := seen[key]
+		switch {
+		case !ok:
+			seen[key] = RowRef{Sheet: sheet, Row: rowNum}
+			*repositories++
+		case first.Sheet != sheet || first.Row != rowNum:
+			add(IssueDuplicate, parsed.FullName(), "与%s重复: %s", first, parsed.FullName())
+		}
+	}
+
+	if !found && len(issues) == 0 {
+		add(IssueInvalidURL, repoCell, "未找到仓库地址")
+	}
+	return issues
+}
+
+// hasDomain 判断地址的第一段是否像域名（如 gitlab.com），
+// 用于区分其他平台的地址和缺少域名的 owner/repo 写法
+func hasDomain(candidate string) bool {
+	if i := strings.Index(candidate, "://"); i != -1 {
+		candidate = candidate[i+len("://"):]
+	}
+	if i := strings.IndexAny(candidate, "/:"); i != -1 {
+		candidate = candidate[:i]
+	}
+	return strings.Contains(candidate, ".")
+}
+
+// logValidation 输出校验报告
+func (p *Processor) logValidation(report *ValidationReport) {
+	for _, issue := range report.Issues {
+		ref := RowRef{Sheet: issue.Sheet, Row: issue.Row}
+		p.logf("⚠️  %s [%s] %s\n", ref, issue.Kind, issue.Message)
+	}
+	if len(report.Issues) > 0 {
+		p.logf("\n")
+	}
+
+	p.logf("🔎 Validation finished: %d rows, %d repositories, %d issues\n", report.Rows, report.Repositories, len(report.Issues))
+	counts := report.Counts()
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		p.logf("  %s: %d\n", kind, counts[kind])
+	}
+}
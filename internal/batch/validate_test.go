@@ -0,0 +1,122 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestProcessor_ValidateSheets(t *testing.T) {
+	sheets := []*Sheet{
+		{Name: "赛道一", Records: [][]string{
+			{"姓名", "代码仓库地址", "平台"},
+			{"A", "https://github.com/team/one"},
+			{"", "https://github.com/team/two", ""},
+			{"C", "https://gitlab.com/team/three", ""},
+			{"D", "https://github.com/team", ""},
+			{"E", "", ""},
+			{"F", "owner/repo", "bitbucket"},
+			{"G", "前端 https://github.com/Team/One 后端 https://gitee.com/team/four", ""},
+		}},
+		{Name: "赛道二", Records: [][]string{
+			{"姓名", "代码仓库地址"},
+			{"H", "https://gitee.com/team/four.git"},
+			{"I", "owner/repo"},
+		}},
+	}
+
+	p := NewProcessor(Options{
+		Columns: ColumnMapping{
+			Repository:  ColumnSelector{Header: ColumnRepository},
+			Participant: ColumnSelector{Header: ColumnName},
+			Platform:    ColumnSelector{Header: "平台"},
+		},
+		NewClient: func(models.Platform) (api.Client, error) {
+			t.Fatal("Dry run must not create API clients")
+			return nil, nil
+		},
+		Log: io.Discard,
+	})
+	report, err := p.ValidateSheets(sheets)
+	if err != nil {
+		t.Fatalf("ValidateSheets failed: %v", err)
+	}
+
+	if report.Rows != 9 || report.Repositories != 3 {
+		t.Errorf("Expected 9 rows and 3 repositories, got %d and %d", report.Rows, report.Repositories)
+	}
+
+	var got []string
+	for _, issue := range report.Issues {
+		got = append(got, fmt.Sprintf("%s:%d:%s", issue.Sheet, issue.Row, issue.Kind))
+	}
+	want := []string{
+		"赛道一:3:missing_name",
+		"赛道一:4:unsupported_platform",
+		"赛道一:5:invalid_url",
+		"赛道一:6:missing_repository",
+		"赛道一:7:unsupported_platform",
+		"赛道一:8:duplicate",
+		"赛道二:2:duplicate",
+		"赛道二:3:invalid_url",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Unexpected issues:\n got %v\nwant %v", got, want)
+	}
+
+	if msg := report.Issues[6].Message; !strings.Contains(msg, "赛道一 第8行") {
+		t.Errorf("Expected duplicate to reference the first row, got %q", msg)
+	}
+	if counts := report.Counts(); counts[IssueDuplicate] != 2 {
+		t.Errorf("Expected 2 duplicates, got %v", counts)
+	}
+}
+
+func TestRun_DryRunWritesValidation(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "sheet.csv")
+	content := "姓名,代码仓库地址\nA,https://github.com/team/one\n,not a url/\n"
+	if err := os.WriteFile(input, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write input: %v", err)
+	}
+
+	summary, err := Run(context.Background(), input, Options{
+		DryRun:          true,
+		WriteValidation: true,
+		NewClient: func(models.Platform) (api.Client, error) {
+			t.Fatal("Dry run must not create API clients")
+			return nil, nil
+		},
+		Log: io.Discard,
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(summary.Validation.Issues) != 2 {
+		t.Errorf("Expected 2 issues, got %+v", summary.Validation.Issues)
+	}
+
+	if summary.OutputFile != ValidationPath(input) {
+		t.Errorf("Expected validation to be written to %s, got %s", ValidationPath(input), summary.OutputFile)
+	}
+	if _, err := os.Stat(OutputPath(input)); !os.IsNotExist(err) {
+		t.Errorf("Expected the result file of a real run to be left alone, got %v", err)
+	}
+
+	records, err := ReadFile(summary.OutputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if records[0][2] != ColumnValidation || records[1][2] != "" {
+		t.Errorf("Unexpected validation column: %v", records)
+	}
+	if !strings.Contains(records[2][2], "姓名为空") || !strings.Contains(records[2][2], "无法解析") {
+		t.Errorf("Expected both issues in row 3, got %q", records[2][2])
+	}
+}
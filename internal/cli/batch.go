@@ -0,0 +1,382 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/batch"
+	"github.com/luoliwoshang/git-event-monitor/internal/config"
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+	"github.com/luoliwoshang/git-event-monitor/internal/webhook"
+)
+
+var (
+	batchTokens   tokenFlags
+	batchDeadline string
+	batchFormat   string
+	batchSummary  string
+	batchTmpl     string
+	batchOutFile  string
+	batchWorkers  int
+	batchCkpt     string
+	batchResume   bool
+	batchReport   string
+	batchNoReport bool
+	batchColumns  columnFlags
+	batchSheet    string
+	batchPattern  string
+	batchHeader   int
+	batchTimezone string
+	batchDryRun   bool
+	batchStart    string
+	batchValidate bool
+	batchNoInfo   bool
+	batchContest  string
+	batchWebhooks string
+	batchCommits  int
+	batchPenalty  penaltyFlags
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch <file> [start-row] [end-row]",
+	Short: "Check every repository listed in a CSV or Excel file",
+	Long: `Check the repositories listed in a CSV or Excel registration sheet.
+
+By default the sheet must contain a "代码仓库地址" column. Results are written
+to the "是否可访问" and "是否准时提交" columns (added when missing) of a copy of
+the file named <file>_processed.csv or <file>_processed.xlsx. Excel results
+are written into a copy of the original workbook: only the result columns of
+the first sheet change (on time in green, late in red, inaccessible in grey),
+and other sheets and formatting are kept.
+
+When a repository has no recent push events, the newest commit on each
+branch before and after the deadline decides the verdict instead. Commit
+dates can be forged, so these results are marked "可信度低" (low confidence).
+
+A cell may list several repositories separated by whitespace, commas,
+semicolons or Chinese punctuation. Every repository is checked: the row is
+on time only when all of them are, and late when any of them is. When any
+row lists several repositories, per-repository details are written to a
+"仓库明细" sheet (Excel) or <file>_processed_repos.csv (CSV).
+
+Columns can be remapped with a YAML/JSON file (--columns) or per-column flags.
+Each column is selected by header name (substring match), by header regex
+("re:^Repo") or by column letter ("col:C"); flags override the file:
+
+  repository: "re:(?i)^repo(sitory)? url$"
+  participant: 参赛者
+  team: { header: 队伍名称 }
+  deadline: col:F
+  platform: 平台
+
+A non-empty deadline cell overrides --deadline for that row; a platform cell
+allows bare "owner/repo" values.
+
+A push within --grace after the deadline counts as on time. With penalty
+tiers (--penalty AFTER:POINTS[:PER_HOUR], repeatable), a "扣分" column is added
+with the penalty of the latest push: POINTS plus PER_HOUR for every started
+hour beyond AFTER, using the last tier whose AFTER has passed.
+
+With --contest, each row is matched to a participant of the contest file by
+repository, then by name, and checked against the rules of the participant's
+track (or the default track): deadline, grace period, penalty tiers, start
+time and allowed branches. --deadline, --start, --grace and --penalty take
+precedence over the track, and a deadline cell over both. A contest file
+looks like:
+
+  name: Hackathon 2025
+  default_track: main
+  tracks:
+    - name: main
+      deadline: "2025-09-30 23:59"
+      timezone: Asia/Shanghai
+      grace: 15m
+      penalties:
+        - { after: 15m, per_hour: 5 }
+        - { name: 一天以上, after: 24h, points: 100 }
+      start: "2025-09-01 09:00"
+      branches: [main, "release/*"]
+  participants:
+    - name: 张三
+      team: 队伍A
+      repos: [https://github.com/team-a/project]
+      track: main
+
+是否准时提交 follows the verdict: results by last push time or with
+conflicting evidence are marked medium confidence, results by commit date low
+confidence. Add the 结论 column (verdict and confidence, e.g. on_time (high))
+with --extra-columns verdict.
+
+With --webhook-store, the pushes recorded by serve-webhooks are added to the
+evidence. Their receive time does not depend on the platform, so a verdict
+that is not high confidence follows the latest recorded push (high
+confidence), and a push recorded after the deadline makes the repository
+late.
+
+Optional result columns (push times, actor, branch, head SHA, late pushes,
+time difference, events checked, verdict, evidence, repository flags, error
+category, earliest push, pushes before start, creation time) are enabled with --extra-columns or an "extra" list in the mapping
+file. Times are shown in --timezone followed by UTC, e.g.
+"2025-09-30 23:59:00 CST (2025-09-30 15:59:00 UTC)".
+
+Status values and time differences are written in --lang (default zh), while
+column headers always stay in Chinese so that reruns find the same columns; the
+checkpoint and report store language-neutral status codes (on_time, late,
+inaccessible, ...), so a run can be resumed or re-rendered in either language.
+
+Deadlines and start times (flags and deadline cells) are RFC3339 or a local
+time such as "2025-09-30 23:59" or "2025/09/30 23:59:59", read in --timezone
+(default: the default track's zone with --contest, else the local time zone).
+Result columns show times in --timezone, which defaults to the deadline's
+zone; the log shows them in both that zone and UTC.
+
+The metadata of every accessible repository is fetched as well. Private,
+forked and archived repositories are flagged, and so are repositories created
+before the contest start when --start is given. With a start time, pushes
+before the start are counted from the recent events (the platforms only keep
+the latest events), and repositories with three or more commits pushed before
+the start (--start-commits, or commits_before_start of the track) are flagged
+as pushed_before_start. After all rows are checked,
+repositories listed in more than one row are reported with their row numbers,
+including owner/repo pairs that differ only in case and repositories that are
+forks of a repository listed in another row. Fetching the metadata costs one
+extra API call per repository; --no-repo-info skips it, which also disables
+the private/fork/archived/created-before-start flags and fork detection.
+
+A machine-readable report is written to <file>_report.ndjson (--report,
+disable with --no-report). Each line is a JSON object: one "row" object per
+processed row with the sheet and row number, the parsed repositories, the
+full analysis result, the commit check, the error category and the verdict of
+each repository with its confidence (high for push events, medium for the
+last push time, low for commit dates) and evidence, one "shared" object per
+duplicate or fork pair, and a final "summary" object with the number of rows
+per verdict (on_time, late, unknown, empty, inaccessible, unchecked,
+skipped).
+
+With --summary markdown or --summary html, a summary is also written to
+<file>_summary.md or <file>_summary.html: the number of repositories per
+verdict, a table with a verdict badge and a link to each repository, and a
+timeline per repository (creation, earliest push, the pushes and commits the
+verdict is based on, and the deadline) with links to the pushes. The HTML page
+has inline styles and loads nothing from the network, so it can be sent as an
+attachment. Texts follow --lang.
+
+--summary template writes <file>_summary.txt with the --template file or
+built-in template (oneline, wechat; see "check --help" for the functions
+available in templates). If the template defines a "summary" template, it is
+executed once with .Entries (row, name, team, track, repository and .Result of
+each repository) and the counts .Total, .OnTime, .Late and .Other; otherwise
+the template is executed once per repository with its analysis result.
+
+--output prints the result of every repository while rows are processed, to
+standard output or to --output-file. table, markdown and templates without a
+"summary" template print each result in turn, json prints an array of the same
+shape as "check --output json" with several repositories, csv prints the header
+once and then one row per repository, and xlsx, html and templates with a
+"summary" template are written once all rows are done. Inaccessible
+repositories are listed in every format. The progress log is written to
+standard error, so standard output carries only the --output results.
+
+Use --dry-run to check a sheet before spending API quota: only the column
+detection and URL parsing run, and rows with a missing or unparseable
+repository URL, an unsupported platform, a repository already listed in
+another row, or a missing name are reported. --write-validation also writes
+the issues of each row to a "校验结果" column of <file>_validation.csv or
+<file>_validation.xlsx, leaving the result file of an earlier run untouched.
+
+Each finished row is appended to a checkpoint file (<file>.checkpoint.jsonl by
+default). If a run is interrupted, rerun it with --resume: rows that already
+have a definitive result are restored from the checkpoint, and rows that
+failed with transient errors (network errors, rate limits, server errors)
+are checked again. The checkpoint records the options that affect results
+(deadline, start, timezone, grace, penalties, contest and column mapping);
+--resume is refused when they differ from the current run.
+
+Row numbers are 1-indexed and inclusive and refer to rows of the sheet; by
+default row 1 is the header, so data starts at row 2. Use --header-row when
+the sheet starts with a title banner; rows above the header are left as is.
+By default every data row is processed.
+
+Excel files are read from the first sheet. Select another one with --sheet
+(name or 1-based index), or process every sheet whose name matches a regex
+with --sheet-pattern; the row range and header row apply to each sheet. Run
+excel-reader -suggest to find the header row and repository column.
+
+Examples:
+  git-event-monitor batch submissions.xlsx --deadline 2025-09-30T23:59:59+08:00
+  git-event-monitor batch submissions.csv 2 67 --github-token ghp_xxx --gitee-token xxx
+  git-event-monitor batch submissions.xlsx --concurrency 8 --github-token ghp_xxx
+  git-event-monitor batch submissions.xlsx --resume --github-token ghp_xxx
+  git-event-monitor batch submissions.xlsx --columns columns.yaml
+  git-event-monitor batch submissions.xlsx --contest contest.yaml
+  git-event-monitor batch submissions.xlsx --summary html
+  git-event-monitor batch submissions.xlsx --summary template --template wechat
+  git-event-monitor batch submissions.xlsx --dry-run --write-validation
+  git-event-monitor batch submissions.xlsx --sheet-pattern "^赛道" --header-row 3
+  git-event-monitor batch submissions.csv --repo-column "col:D" --team-column 队伍
+  git-event-monitor batch submissions.xlsx --extra-columns last_push,actor,late_pushes --timezone Asia/Shanghai`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: runBatch,
+	// 结果列的表头是中文，状态值默认也使用中文
+	Annotations: map[string]string{langAnnotation: string(i18n.Chinese)},
+}
+
+func init() {
+	batchTokens.register(batchCmd)
+	batchColumns.register(batchCmd)
+	batchCmd.Flags().StringVar(&batchSheet, "sheet", "", "Excel sheet to process (name or 1-based index, default first sheet)")
+	batchCmd.Flags().StringVar(&batchPattern, "sheet-pattern", "", "Process every Excel sheet whose name matches this regex")
+	batchCmd.Flags().IntVar(&batchHeader, "header-row", 1, "Row containing the column headers (1-indexed)")
+	batchCmd.Flags().StringVar(&batchTimezone, "timezone", "", "Time zone for deadlines without an offset and for displayed times, e.g. Asia/Shanghai (default: the deadline's zone, or local)")
+	batchCmd.Flags().StringVar(&batchDeadline, "deadline", "", "Deadline for compliance check, RFC3339 or \"2006-01-02 15:04[:05]\" in --timezone")
+	batchCmd.Flags().StringVar(&batchFormat, "output", "", "Also print each analysis result (table, json, markdown, html or template)")
+	batchCmd.Flags().StringVar(&batchOutFile, "output-file", "", "Write the --output results to this file instead of standard output")
+	batchCmd.Flags().StringVar(&batchSummary, "summary", "", "Also write a summary to <file>_summary.md, .html or .txt (markdown, html or template)")
+	batchCmd.Flags().StringVar(&batchTmpl, "template", "", "With --output template or --summary template: a text/template file, or a built-in template (oneline, wechat)")
+	batchCmd.Flags().IntVar(&batchWorkers, "concurrency", 1, "Number of rows processed in parallel (API quota is shared across workers)")
+	batchCmd.Flags().StringVar(&batchCkpt, "checkpoint", "", "Checkpoint file (default <file>.checkpoint.jsonl)")
+	batchCmd.Flags().BoolVar(&batchResume, "resume", false, "Skip rows already completed in the checkpoint file")
+	batchCmd.Flags().StringVar(&batchReport, "report", "", "NDJSON report file (default <file>_report.ndjson)")
+	batchCmd.Flags().BoolVar(&batchNoReport, "no-report", false, "Do not write the NDJSON report")
+	batchPenalty.register(batchCmd)
+	batchCmd.Flags().StringVar(&batchContest, "contest", "", "Contest file (YAML or JSON) with per-track deadlines, start times and branches")
+	batchCmd.Flags().StringVar(&batchWebhooks, "webhook-store", "", "JSONL store written by serve-webhooks; recorded pushes are used as high-confidence evidence")
+	batchCmd.Flags().BoolVar(&batchNoInfo, "no-repo-info", false, "Do not fetch repository metadata (saves one API call per repository; disables repository flags and fork detection)")
+	batchCmd.Flags().StringVar(&batchStart, "start", "", "Contest start, same formats as --deadline; repositories created earlier are flagged")
+	batchCmd.Flags().IntVar(&batchCommits, "start-commits", 0, "Flag repositories with at least this many commits pushed before the start (default: the track's commits_before_start, or 3)")
+	batchCmd.Flags().BoolVar(&batchDryRun, "dry-run", false, "Only validate the sheet (no API calls)")
+	batchCmd.Flags().BoolVar(&batchValidate, "write-validation", false, "With --dry-run, write the issues of each row to a \"校验结果\" column")
+}
+
+// newBatchClient 创建 batch 使用的 API 客户端，为空时使用 batch 的默认客户端；测试中替换
+var newBatchClient func(models.Platform) (api.Client, error)
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	columns, err := batchColumns.mapping()
+	if err != nil {
+		return err
+	}
+
+	opts := batch.Options{
+		Columns:         columns,
+		Sheet:           batchSheet,
+		SheetPattern:    batchPattern,
+		HeaderRow:       batchHeader,
+		TokenFor:        batchTokens.forPlatform,
+		Log:             cmd.ErrOrStderr(),
+		NewClient:       newBatchClient,
+		Concurrency:     batchWorkers,
+		CheckpointPath:  batchCkpt,
+		Resume:          batchResume,
+		DryRun:          batchDryRun,
+		WriteValidation: batchValidate,
+		SkipRepoInfo:    batchNoInfo,
+	}
+	if batchCommits < 0 {
+		return fmt.Errorf("--start-commits must not be negative")
+	}
+	opts.CommitsBeforeStart = batchCommits
+	if opts.Policy, err = batchPenalty.policy(); err != nil {
+		return err
+	}
+	opts.GraceSet, opts.PenaltySet = batchPenalty.changed(cmd)
+	if opts.Location, err = parseTimezone(batchTimezone); err != nil {
+		return err
+	}
+	if batchContest != "" {
+		if opts.Contest, err = config.Load(batchContest); err != nil {
+			return err
+		}
+		// 没有指定时区时，按默认赛道的时区解析和显示时间
+		if track := opts.Contest.TrackFor(nil); track != nil && opts.Location == nil {
+			opts.Location = track.Location()
+		}
+	}
+	if batchWebhooks != "" {
+		if opts.Webhooks, err = webhook.LoadRecords(batchWebhooks); err != nil {
+			return err
+		}
+	}
+	if batchStart != "" {
+		if opts.Start, err = monitor.ParseTime(batchStart, opts.Location); err != nil {
+			return fmt.Errorf("invalid start time: %w", err)
+		}
+	}
+	if batchDeadline != "" {
+		deadlineTime, err := monitor.ParseTime(batchDeadline, opts.Location)
+		if err != nil {
+			return fmt.Errorf("invalid deadline: %w", err)
+		}
+		opts.Deadline = deadlineTime.Format(time.RFC3339)
+		if opts.Location == nil {
+			opts.Location = deadlineTime.Location()
+		}
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.CheckpointPath == "" {
+		opts.CheckpointPath = batch.CheckpointPath(args[0])
+	}
+	if batchValidate && !batchDryRun {
+		return fmt.Errorf("--write-validation requires --dry-run")
+	}
+	if !batchNoReport && !batchDryRun {
+		opts.ReportPath = batchReport
+		if opts.ReportPath == "" {
+			opts.ReportPath = batch.ReportPath(args[0])
+		}
+	}
+
+	if len(args) > 1 {
+		if opts.StartRow, err = strconv.Atoi(args[1]); err != nil {
+			return fmt.Errorf("invalid start row: %s", args[1])
+		}
+	}
+	if len(args) > 2 {
+		if opts.EndRow, err = strconv.Atoi(args[2]); err != nil {
+			return fmt.Errorf("invalid end row: %s", args[2])
+		}
+	}
+
+	if batchFormat != "" {
+		if opts.Formatter, err = newFormatter(batchFormat, batchTmpl, opts.Location); err != nil {
+			return err
+		}
+	} else if batchOutFile != "" {
+		return fmt.Errorf("--output-file requires --output")
+	}
+	switch batchSummary {
+	case "", "markdown", "html":
+	case "template":
+		// 在处理之前检查模板，避免处理完成后才发现模板有误
+		if _, err := output.NewTemplateFormatter(batchTmpl, opts.Location); err != nil {
+			return err
+		}
+		opts.SummaryTemplate = batchTmpl
+	default:
+		return fmt.Errorf("invalid --summary format: %s (supported: markdown, html, template)", batchSummary)
+	}
+	opts.SummaryFormat = batchSummary
+
+	closeOutput := func() error { return nil }
+	if opts.Formatter != nil {
+		if opts.FormatterOut, closeOutput, err = openOutput(cmd, batchOutFile); err != nil {
+			return err
+		}
+	}
+
+	_, err = batch.Run(context.Background(), args[0], opts)
+	if closeErr := closeOutput(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+)
+
+func TestBatch_OutputOnStdoutLogOnStderr(t *testing.T) {
+	client := &stubClient{missing: map[string]bool{"team/missing": true}}
+	defer func(original func(models.Platform) (api.Client, error)) { newBatchClient = original }(newBatchClient)
+	newBatchClient = func(models.Platform) (api.Client, error) { return client, nil }
+
+	path := filepath.Join(t.TempDir(), "sheet.csv")
+	content := "姓名,代码仓库地址\nA,https://github.com/team/ok\nB,https://github.com/team/missing\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write sheet: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"batch", path, "--deadline", "2025-09-30T16:00:00Z", "--output", "json"})
+	defer rootCmd.SetArgs(nil)
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("batch failed: %v\n%s", err, stderr.String())
+	}
+
+	var entries []output.SummaryEntry
+	if err := json.Unmarshal(stdout.Bytes(), &entries); err != nil {
+		t.Fatalf("Expected only the JSON array on stdout: %v\n%s", err, stdout.String())
+	}
+	if len(entries) != 2 || entries[0].Repository != "team/ok" || entries[1].Repository != "team/missing" {
+		t.Fatalf("Expected both repositories, got %+v", entries)
+	}
+	if verdict := entries[1].Result.Verdict; verdict == nil || verdict.Status != models.VerdictInaccessible {
+		t.Errorf("Expected team/missing to be inaccessible, got %+v", verdict)
+	}
+	if !strings.Contains(stderr.String(), "Starting batch processing") {
+		t.Errorf("Expected the progress log on stderr, got:\n%s", stderr.String())
+	}
+}
@@ -2,89 +2,418 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/luoliwoshang/git-event-monitor/internal/api"
-	"github.com/luoliwoshang/git-event-monitor/internal/api/github"
-	"github.com/luoliwoshang/git-event-monitor/internal/api/gitee"
+	"github.com/luoliwoshang/git-event-monitor/internal/config"
 	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
 	"github.com/luoliwoshang/git-event-monitor/internal/output"
+	"github.com/luoliwoshang/git-event-monitor/internal/platform"
+	"github.com/luoliwoshang/git-event-monitor/internal/webhook"
 )
 
 var (
-	platform string
-	token    string
-	deadline string
-	format   string
+	platformName string
+	checkTokens  tokenFlags
+	deadline     string
+	start        string
+	format       string
+	checkTmpl    string
+	checkOutput  string
+	checkContest string
+	checkZone    string
+	checkHooks   string
+	checkCommits int
+	checkPenalty penaltyFlags
 )
 
+// newCheckClient 创建 check 使用的 API 客户端，测试中替换
+var newCheckClient = platform.NewClient
+
 var checkCmd = &cobra.Command{
-	Use:   "check <owner/repo>",
+	Use:   "check <owner/repo>...",
 	Short: "Check repository code submission events",
-	Long: `Check the latest code submission events for a repository.
+	Long: `Check the latest code submission events for one or more repositories.
+
+When no push event is found and a deadline is given, the newest commits
+before and after the deadline are checked instead, on the track's branches
+when the contest sets them and otherwise on the default branch (at most 10
+branches). Commit dates can be forged, so such a verdict is marked as low
+confidence.
+
+The verdict (on_time, late, unknown, empty or inaccessible) is shown with its
+confidence and the evidence it is based on: push events (high), the
+repository's last push time (medium) and commit dates (low). With
+--webhook-store, the pushes recorded by serve-webhooks are added as well:
+a verdict that is not high confidence follows the latest recorded push, and
+a push recorded after the deadline makes the repository late.
+
+A push within --grace after the deadline counts as on time. Later pushes fall
+into the penalty tiers given with --penalty AFTER:POINTS[:PER_HOUR]: a push
+more than AFTER late costs POINTS plus PER_HOUR for every started hour beyond
+AFTER, e.g. --grace 15m --penalty 15m:0:5 --penalty 24h:100.
+
+With --contest, the deadline, grace period, penalty tiers, start time and
+allowed branches come from the track of the participant who registered the
+repository, or from the default track; --deadline, --grace, --penalty and
+--start still take precedence. Each flag replaces only its own part of the
+track's rules: --grace alone keeps the track's penalty tiers, and --penalty
+alone keeps its grace period. Pushes to other branches are ignored.
+
+The repository metadata is shown as well. Private, forked and archived
+repositories are flagged. With --start, the earliest push in the recent
+events and the number of pushes and commits before the start are shown;
+repositories created before the start, or with three or more commits pushed
+before it (--start-commits, or commits_before_start of the contest track), are
+flagged too.
+
+Deadlines and start times are RFC3339 ("2024-03-15T18:00:00+08:00") or a
+local time such as "2024-03-15 18:00" or "2024/03/15 18:00:00", read in
+--timezone (default: the contest track's zone, or the local time zone). Times are shown in UTC and in
+--timezone, which defaults to the deadline's zone (or the contest track's).
+
+--output markdown prints a post with the verdict badge, the key facts and a
+timeline of the repository creation, the earliest push, the evidence and the
+deadline, with links to the pushes. --output html prints the same as a
+self-contained page (inline styles, no external resources).
+
+--output template --template FILE executes a Go text/template with the
+analysis result (see models.AnalysisResult) as data. Besides the fields of the
+result, templates can use these functions:
+
+  localTime VALUE         RFC3339 time in --timezone, e.g. "2024-03-15 18:00:00 CST"
+  dualTime VALUE          the same followed by the UTC time
+  formatTime LAYOUT VALUE RFC3339 time in --timezone with a Go layout ("01-02 15:04")
+  inZone ZONE VALUE       RFC3339 time in another time zone ("Asia/Tokyo")
+  duration SECONDS        duration in --lang, e.g. {{duration .TimeDifferenceSeconds}}
+  mask TEXT               hide the middle of a name or login ("a***e")
+  repo RESULT             owner/repo of the result
+  verdictLabel, verdictEmoji VERDICT
+                          verdict text in --lang and its colored marker
+  t KEY ARGS...           a message of the --lang catalogue
+  join LIST SEP           strings.Join
+
+The built-in templates "oneline" (one line per repository) and "wechat" (a
+plain-text notice for chat groups) can be given instead of a file.
+
+Several repositories can be checked at once. --output csv and --output xlsx
+write one row per repository with the columns of the batch repository
+details (row, name, team, repository URL, platform, repository, access and
+submission; the row is left empty) followed by every optional batch result
+column (see "batch --help"). The headers stay in Chinese whatever --lang is;
+only the values are translated. With --contest, the name and team come from the
+contest file. With several repositories, json prints an array, markdown,
+html and templates with a "summary" template print a single summary, and
+table prints each result in turn. A repository whose analysis fails is listed
+as inaccessible (not found or no access) or unknown (network errors, rate
+limits) and the others are still checked; the command then exits with an
+error after printing the results.
+
+Results are printed to standard output, or written to --output-file.
 
 Examples:
   git-event-monitor check microsoft/vscode
   git-event-monitor check microsoft/vscode --platform github --token ghp_xxxxx
-  git-event-monitor check owner/repo --platform gitee --deadline "2024-03-15T18:00:00Z"`,
-	Args: cobra.ExactArgs(1),
+  git-event-monitor check owner/repo --platform gitee --deadline "2024-03-15T18:00:00Z"
+  git-event-monitor check owner/repo --start "2024-03-01T00:00:00Z" --deadline "2024-03-15T18:00:00Z"
+  git-event-monitor check owner/repo --deadline "2024-03-15 18:00" --timezone Asia/Shanghai
+  git-event-monitor check owner/repo --contest contest.yaml
+  git-event-monitor check owner/repo --deadline "2024-03-15 18:00" --output html > report.html
+  git-event-monitor check owner/repo --output template --template wechat
+  git-event-monitor check owner/repo --output template --template notice.tmpl
+  git-event-monitor check team/a team/b team/c --deadline "2024-03-15 18:00" --output csv > results.csv
+  git-event-monitor check team/a team/b --contest contest.yaml --output xlsx --output-file results.xlsx`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: runCheck,
 }
 
 func init() {
-	checkCmd.Flags().StringVar(&platform, "platform", "github", "Platform to check (github or gitee)")
-	checkCmd.Flags().StringVar(&token, "token", "", "API token (optional for public repos)")
-	checkCmd.Flags().StringVar(&deadline, "deadline", "", "Deadline for compliance check (ISO 8601 format)")
-	checkCmd.Flags().StringVar(&format, "output", "table", "Output format (table or json)")
+	checkCmd.Flags().StringVar(&platformName, "platform", "github", "Platform to check (github or gitee)")
+	checkCmd.Flags().StringVar(&checkTokens.token, "token", "", "API token (optional for public repos)")
+	checkTokens.register(checkCmd)
+	checkCmd.Flags().StringVar(&deadline, "deadline", "", "Deadline for compliance check, RFC3339 or \"2006-01-02 15:04[:05]\" in --timezone")
+	checkCmd.Flags().StringVar(&start, "start", "", "Contest start, same formats as --deadline; repositories created earlier are flagged")
+	checkCmd.Flags().StringVar(&checkZone, "timezone", "", "Time zone for deadlines without an offset and for displayed times, e.g. Asia/Shanghai (default: the deadline's zone, or local)")
+	checkCmd.Flags().StringVar(&format, "output", "table", "Output format (table, json, markdown, html, template, csv or xlsx)")
+	checkCmd.Flags().StringVar(&checkOutput, "output-file", "", "Write the result to this file instead of standard output")
+	checkCmd.Flags().StringVar(&checkTmpl, "template", "", "With --output template: a text/template file, or a built-in template (oneline, wechat)")
+	checkPenalty.register(checkCmd)
+	checkCmd.Flags().IntVar(&checkCommits, "start-commits", 0, "Flag repositories with at least this many commits pushed before the start (default: the track's commits_before_start, or 3)")
+	checkCmd.Flags().StringVar(&checkHooks, "webhook-store", "", "JSONL store written by serve-webhooks; recorded pushes are used as high-confidence evidence")
+	checkCmd.Flags().StringVar(&checkContest, "contest", "", "Contest file (YAML or JSON); the repository's track sets the deadline, start and branches")
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
-	repo := args[0]
-
 	// 验证仓库名格式
-	if !strings.Contains(repo, "/") {
-		return fmt.Errorf("repository format should be 'owner/repo'")
+	for _, repo := range args {
+		if !strings.Contains(repo, "/") {
+			return fmt.Errorf("repository format should be 'owner/repo': %s", repo)
+		}
 	}
 
 	// 验证平台
-	var platformType models.Platform
-	switch platform {
-	case "github":
-		platformType = models.PlatformGitHub
-	case "gitee":
-		platformType = models.PlatformGitee
-	default:
-		return fmt.Errorf("unsupported platform: %s (supported: github, gitee)", platform)
+	platformType, err := platform.Parse(platformName)
+	if err != nil {
+		return err
+	}
+
+	policy, err := checkPenalty.policy()
+	if err != nil {
+		return err
+	}
+
+	zone, err := parseTimezone(checkZone)
+	if err != nil {
+		return err
+	}
+
+	var contest *config.Contest
+	if checkContest != "" {
+		if contest, err = config.Load(checkContest); err != nil {
+			return err
+		}
+	}
+
+	var webhooks []*webhook.Record
+	if checkHooks != "" {
+		if webhooks, err = webhook.LoadRecords(checkHooks); err != nil {
+			return err
+		}
+	}
+
+	// 先解析所有仓库的规则，参数有误时不调用 API
+	targets := make([]*checkTarget, len(args))
+	for i, repo := range args {
+		if targets[i], err = newCheckTarget(cmd, repo, platformType, contest, zone, policy); err != nil {
+			return err
+		}
+		targets[i].webhooks = webhook.EventsForRepo(webhooks, platformType, repo)
+	}
+	// 没有指定时区时，按第一个仓库的赛道或截止时间的时区显示时间
+	if zone == nil {
+		zone = targets[0].zone
 	}
 
-	// 创建分析请求
-	req := &models.AnalysisRequest{
-		Repository: repo,
-		Platform:   platformType,
-		Token:      token,
-		Deadline:   deadline,
+	// 在调用 API 之前加载模板，模板有误时直接报错
+	formatter, err := newFormatter(format, checkTmpl, zone)
+	if err != nil {
+		return err
 	}
 
 	// 创建对应平台的客户端
-	var client api.Client
-	switch platformType {
-	case models.PlatformGitHub:
-		client = github.NewClient()
-	case models.PlatformGitee:
-		client = gitee.NewClient()
+	client, err := newCheckClient(platformType)
+	if err != nil {
+		return err
 	}
 
-	// 执行分析
 	ctx := context.Background()
+	entries := make([]output.SummaryEntry, 0, len(targets))
+	failed := 0
+	for _, target := range targets {
+		if len(targets) > 1 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "🔍 %s\n", target.req.Repository)
+		}
+		if !target.deadline.IsZero() {
+			fmt.Fprintf(cmd.ErrOrStderr(), "🎯 Deadline: %s\n", monitor.DualTime(target.deadline, zone))
+		}
+
+		result, err := target.analyze(ctx, cmd, client)
+		if err != nil {
+			// 只检查一个仓库时直接报错；多个仓库时记录失败并继续检查其余仓库
+			if len(targets) == 1 {
+				return fmt.Errorf("analysis of %s failed: %w", target.req.Repository, err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "❌ Analysis of %s failed: %v\n", target.req.Repository, err)
+			result = failedResult(target.req, err)
+			failed++
+		}
+		target.entry.Result = result
+		if result.Repository != nil {
+			target.entry.URL = result.Repository.HTMLURL
+		}
+		entries = append(entries, target.entry)
+	}
+
+	// 输出结果：多个仓库时写成一份结果（csv 只有一个表头、json 为数组、markdown 和 html 为汇总）
+	w, closeOutput, err := openOutput(cmd, checkOutput)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 1 {
+		err = formatter.FormatSummary(w, entries)
+	} else {
+		err = formatter.Format(w, entries[0].Result)
+	}
+	if closeErr := closeOutput(); err == nil {
+		err = closeErr
+	}
+	if err == nil && failed > 0 {
+		// 结果已经输出，不再显示用法
+		cmd.SilenceUsage = true
+		err = fmt.Errorf("%d of %d repositories could not be analyzed", failed, len(entries))
+	}
+	return err
+}
+
+// failedResult 分析失败的仓库的结果：仓库不存在或无权访问时为不可访问，其余（网络错误、限流等）为无法确定
+func failedResult(req *models.AnalysisRequest, err error) *models.AnalysisResult {
+	result := &models.AnalysisResult{
+		Deadline:  req.Deadline,
+		Error:     err.Error(),
+		ErrorCode: api.ErrorCategory(err),
+		Verdict:   &models.Verdict{Status: models.VerdictUnknown},
+	}
+	var statusErr *api.StatusError
+	if errors.As(err, &statusErr) && !statusErr.Temporary() {
+		result.Verdict = &models.Verdict{Status: models.VerdictInaccessible, Confidence: models.ConfidenceHigh}
+	}
+	return result
+}
+
+// checkTarget 一个待检查仓库的分析请求和规则
+type checkTarget struct {
+	req      *models.AnalysisRequest
+	start    time.Time
+	deadline time.Time
+	policy   models.LatenessPolicy
+	// startCommits 标记开始前推送较多提交的阈值，为 0 时使用默认值
+	startCommits int
+	// zone 按赛道或截止时间推断的显示时区
+	zone *time.Location
+	// entry 输出用的参赛者、赛道和仓库信息
+	entry output.SummaryEntry
+	// webhooks 该仓库的 Webhook 事件（按接收时间倒序）
+	webhooks []*models.UnifiedEvent
+}
+
+// newCheckTarget 按比赛配置和命令行参数确定仓库的截止时间、开始时间、扣分规则和分支
+// 比赛配置中的赛道规则，命令行参数优先；不带时区的 --deadline 和 --start 按 zone（为空时为赛道时区）解析
+func newCheckTarget(cmd *cobra.Command, repo string, platformType models.Platform, contest *config.Contest, zone *time.Location, policy models.LatenessPolicy) (*checkTarget, error) {
+	t := &checkTarget{
+		req: &models.AnalysisRequest{
+			Repository: repo,
+			Platform:   platformType,
+			Token:      checkTokens.forPlatform(platformType),
+		},
+		policy: policy,
+		zone:   zone,
+		entry:  output.SummaryEntry{Repository: repo, Platform: platformType},
+	}
+
+	if contest != nil {
+		participant, track, err := contestTrack(cmd, contest, platformType, repo)
+		if err != nil {
+			return nil, err
+		}
+		if participant != nil {
+			t.entry.Name = participant.Name
+			t.entry.Team = participant.Team
+		}
+		t.entry.Track = track.Name
+		if t.zone == nil {
+			t.zone = track.Location()
+		}
+		t.deadline = track.DeadlineTime().In(track.Location())
+		graceSet, tiersSet := checkPenalty.changed(cmd)
+		t.policy = track.MergePolicy(policy, graceSet, tiersSet)
+		t.start = track.StartTime()
+		t.startCommits = track.CommitsBeforeStart
+		t.req.Branches = track.Branches
+	}
+
+	if checkCommits < 0 {
+		return nil, fmt.Errorf("--start-commits must not be negative")
+	}
+	if checkCommits > 0 {
+		t.startCommits = checkCommits
+	}
+
+	var err error
+	if start != "" {
+		if t.start, err = monitor.ParseTime(start, t.zone); err != nil {
+			return nil, fmt.Errorf("invalid start time: %w", err)
+		}
+	}
+	if deadline != "" {
+		if t.deadline, err = monitor.ParseTime(deadline, t.zone); err != nil {
+			return nil, fmt.Errorf("invalid deadline: %w", err)
+		}
+	}
+	if !t.start.IsZero() {
+		t.req.Start = t.start.Format(time.RFC3339)
+	}
+	if !t.deadline.IsZero() {
+		t.req.Deadline = t.deadline.Format(time.RFC3339)
+		// 没有指定时区时，按截止时间的时区显示时间
+		if t.zone == nil {
+			t.zone = t.deadline.Location()
+		}
+	}
+	if t.zone == nil {
+		t.zone = time.Local
+	}
+	return t, nil
+}
+
+// analyze 分析仓库的代码事件，并补充提交时间、迟交档位、仓库信息和结论
+func (t *checkTarget) analyze(ctx context.Context, cmd *cobra.Command, client api.Client) (*models.AnalysisResult, error) {
+	req := t.req
 	result, err := client.AnalyzeCodeEvents(ctx, req)
 	if err != nil {
-		return fmt.Errorf("analysis failed: %w", err)
+		return nil, err
+	}
+
+	// 仓库信息只用于补充标记和确定默认分支，获取失败不影响分析结果
+	info, infoErr := client.GetRepository(ctx, req.Repository, req.Token)
+	if infoErr != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  Failed to get repository info: %v\n", infoErr)
+	} else {
+		result.Repository = info
+		result.Flags = monitor.RepositoryFlags(info, t.start)
 	}
 
-	// 输出结果
-	formatter := output.NewFormatter(format)
-	return formatter.Format(result)
+	// 客户端把获取事件失败记录在 result.Error 中（没有 ErrorCode）而不返回错误，按分析失败处理；
+	// 仓库信息的请求错误带有状态码，可以区分仓库不存在或无权访问
+	if !result.Found && result.ErrorCode == "" && result.Error != "" {
+		var statusErr *api.StatusError
+		if errors.As(infoErr, &statusErr) {
+			return nil, infoErr
+		}
+		return nil, errors.New(result.Error)
+	}
+
+	// 没有推送事件时，按默认分支（或赛道分支）的提交时间判断（低可信度）
+	empty := false
+	if !result.Found && !t.deadline.IsZero() {
+		if branches, err := client.GetBranchCommits(ctx, req.Repository, req.Token, t.deadline, monitor.CommitBranches(req.Branches, info)); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  Failed to check branch commits: %v\n", err)
+		} else {
+			empty = !monitor.ApplyBranchCommits(result, branches, t.deadline)
+		}
+	}
+
+	// 宽限时间内的推送视为准时，超过宽限时间后按扣分档位计算扣分
+	if !t.policy.IsZero() && !t.deadline.IsZero() {
+		monitor.ApplyLateness(result, t.deadline, t.policy)
+	}
+
+	result.Flags = append(result.Flags, monitor.ActivityFlags(result, t.startCommits)...)
+
+	var statusErr *api.StatusError
+	switch {
+	case !result.Found && errors.As(infoErr, &statusErr) && !statusErr.Temporary():
+		result.Verdict = &models.Verdict{Status: models.VerdictInaccessible, Confidence: models.ConfidenceHigh}
+	case empty:
+		result.Verdict = &models.Verdict{Status: models.VerdictEmpty, Confidence: models.ConfidenceHigh}
+	default:
+		result.Verdict = monitor.Evaluate(result, result.Repository, t.deadline, t.policy.Grace)
+		monitor.ApplyWebhookEvents(result.Verdict, t.webhooks, t.deadline, t.policy.Grace, req.Branches)
+	}
+	return result, nil
 }
\ No newline at end of file
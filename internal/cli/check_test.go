@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+)
+
+// stubClient 测试用客户端：missing 中的仓库返回 404，其余仓库有一次截止前的推送
+// AnalyzeCodeEvents 与 GitHub、Gitee 客户端一样把获取事件的错误记录在结果中而不返回
+type stubClient struct {
+	missing map[string]bool
+}
+
+func (s *stubClient) GetEvents(ctx context.Context, repo string, token string) ([]*models.UnifiedEvent, error) {
+	if s.missing[repo] {
+		return nil, &api.StatusError{StatusCode: 404}
+	}
+	return []*models.UnifiedEvent{{BaseEvent: models.BaseEvent{Type: "PushEvent", CreatedAt: "2025-09-30T10:00:00Z"}}}, nil
+}
+
+func (s *stubClient) AnalyzeCodeEvents(ctx context.Context, req *models.AnalysisRequest) (*models.AnalysisResult, error) {
+	events, err := s.GetEvents(ctx, req.Repository, req.Token)
+	if err != nil {
+		return &models.AnalysisResult{Found: false, Error: err.Error()}, nil
+	}
+	onTime := true
+	return &models.AnalysisResult{
+		Found:           true,
+		EventsChecked:   len(events),
+		LastCodeEvent:   events[0],
+		Deadline:        req.Deadline,
+		SubmittedBefore: &onTime,
+	}, nil
+}
+
+func (s *stubClient) HasCommits(ctx context.Context, repo string, token string) (bool, error) {
+	return !s.missing[repo], nil
+}
+
+func (s *stubClient) GetBranchCommits(ctx context.Context, repo string, token string, deadline time.Time, branches []string) ([]*models.BranchCommits, error) {
+	return nil, nil
+}
+
+func (s *stubClient) GetRepository(ctx context.Context, repo string, token string) (*models.Repository, error) {
+	if s.missing[repo] {
+		return nil, &api.StatusError{StatusCode: 404}
+	}
+	return &models.Repository{FullName: repo, Visibility: models.VisibilityPublic}, nil
+}
+
+func (s *stubClient) GetPlatform() models.Platform {
+	return models.PlatformGitHub
+}
+
+func TestCheck_FailedRepositoryExitsWithError(t *testing.T) {
+	client := &stubClient{missing: map[string]bool{"team/missing": true}}
+	defer func(original func(models.Platform) (api.Client, error)) { newCheckClient = original }(newCheckClient)
+	newCheckClient = func(models.Platform) (api.Client, error) { return client, nil }
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"check", "team/ok", "team/missing", "--deadline", "2025-09-30T16:00:00Z", "--output", "json"})
+	defer rootCmd.SetArgs(nil)
+
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("Expected an error when a repository could not be analyzed")
+	}
+
+	var entries []output.SummaryEntry
+	if err := json.Unmarshal(stdout.Bytes(), &entries); err != nil {
+		t.Fatalf("Expected a JSON array on stdout: %v\n%s", err, stdout.String())
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected both repositories in the output, got %d", len(entries))
+	}
+	if verdict := entries[0].Result.Verdict; verdict == nil || verdict.Status != models.VerdictOnTime {
+		t.Errorf("Expected team/ok to be on time, got %+v", verdict)
+	}
+	missing := entries[1].Result
+	if missing.Verdict == nil || missing.Verdict.Status != models.VerdictInaccessible || missing.ErrorCode != api.ErrorNotFound {
+		t.Errorf("Expected team/missing to be inaccessible (not_found), got %+v / %s", missing.Verdict, missing.ErrorCode)
+	}
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/batch"
+)
+
+// columnFlags 表格列映射参数
+// 每个列参数支持表头名称、"re:<正则>" 和 "col:<列字母>" 三种写法，优先于配置文件
+type columnFlags struct {
+	config  string
+	columns map[string]*string
+	extra   []string
+}
+
+// columnFlagNames 列参数名称，与 ColumnMapping 的字段一一对应
+var columnFlagNames = []struct {
+	flag  string
+	usage string
+	field func(*batch.ColumnMapping) *batch.ColumnSelector
+}{
+	{"repo-column", "Repository URL column", func(m *batch.ColumnMapping) *batch.ColumnSelector { return &m.Repository }},
+	{"participant-column", "Participant name column", func(m *batch.ColumnMapping) *batch.ColumnSelector { return &m.Participant }},
+	{"team-column", "Team name column", func(m *batch.ColumnMapping) *batch.ColumnSelector { return &m.Team }},
+	{"access-column", "Result column for accessibility (added when missing)", func(m *batch.ColumnMapping) *batch.ColumnSelector { return &m.Access }},
+	{"submission-column", "Result column for on-time submission (added when missing)", func(m *batch.ColumnMapping) *batch.ColumnSelector { return &m.Submission }},
+	{"deadline-column", "Per-row deadline column (overrides --deadline when not empty)", func(m *batch.ColumnMapping) *batch.ColumnSelector { return &m.Deadline }},
+	{"platform-column", "Per-row platform column (github or gitee)", func(m *batch.ColumnMapping) *batch.ColumnSelector { return &m.Platform }},
+}
+
+// register 注册列映射参数
+func (c *columnFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.config, "columns", "", "Column mapping file (YAML or JSON)")
+	c.columns = make(map[string]*string)
+	for _, f := range columnFlagNames {
+		c.columns[f.flag] = cmd.Flags().String(f.flag, "", f.usage+` (header name, "re:<regex>" or "col:<letter>")`)
+	}
+	cmd.Flags().StringSliceVar(&c.extra, "extra-columns", nil, "Optional result columns to add (comma-separated, or \"all\"): "+extraColumnList())
+}
+
+// extraColumnList 返回可选结果列的名称列表
+func extraColumnList() string {
+	keys := batch.ExtraColumnKeys()
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// mapping 合并配置文件和命令行参数，得到最终的列映射
+func (c *columnFlags) mapping() (batch.ColumnMapping, error) {
+	mapping := batch.DefaultColumnMapping()
+	if c.config != "" {
+		var err error
+		if mapping, err = batch.LoadColumnMapping(c.config); err != nil {
+			return mapping, err
+		}
+	}
+
+	for _, f := range columnFlagNames {
+		value := *c.columns[f.flag]
+		if value == "" {
+			continue
+		}
+		sel, err := batch.ParseColumnSelector(value)
+		if err != nil {
+			return mapping, fmt.Errorf("invalid --%s: %w", f.flag, err)
+		}
+		*f.field(&mapping) = sel
+	}
+
+	if c.extra != nil {
+		extra, err := batch.ParseExtraColumns(c.extra)
+		if err != nil {
+			return mapping, fmt.Errorf("invalid --extra-columns: %w", err)
+		}
+		mapping.Extra = extra
+	}
+
+	return mapping, nil
+}
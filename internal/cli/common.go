@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/config"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
+	"github.com/luoliwoshang/git-event-monitor/internal/output"
+)
+
+// tokenFlags API Token 参数，check 和 batch 共用
+type tokenFlags struct {
+	token  string
+	github string
+	gitee  string
+}
+
+// register 注册按平台区分的 Token 参数
+func (t *tokenFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&t.github, "github-token", "", "GitHub API token")
+	cmd.Flags().StringVar(&t.gitee, "gitee-token", "", "Gitee API token")
+}
+
+// forPlatform 返回指定平台使用的 Token，平台专用 Token 优先于 --token
+func (t *tokenFlags) forPlatform(p models.Platform) string {
+	switch {
+	case p == models.PlatformGitHub && t.github != "":
+		return t.github
+	case p == models.PlatformGitee && t.gitee != "":
+		return t.gitee
+	default:
+		return t.token
+	}
+}
+
+// contestTrack 返回仓库所属的参赛者（不在名单中时为 nil）和赛道（不在名单中时为默认赛道）
+func contestTrack(cmd *cobra.Command, contest *config.Contest, p models.Platform, repo string) (*config.Participant, *config.Track, error) {
+	participant := contest.FindByRepository(p, repo)
+	track := contest.TrackFor(participant)
+	if track == nil {
+		return nil, nil, fmt.Errorf("%s is not listed in the contest and no default track is set", repo)
+	}
+
+	if participant != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "🏁 Participant: %s, track: %s\n", participant.Name, track.Name)
+	} else {
+		fmt.Fprintf(cmd.ErrOrStderr(), "🏁 Not listed in the contest, using default track: %s\n", track.Name)
+	}
+	return participant, track, nil
+}
+
+// newFormatter 创建结果格式化器，format 为 template 时从 tmpl（文件或内置模板名称）加载模板
+func newFormatter(format, tmpl string, loc *time.Location) (output.Formatter, error) {
+	if format == "template" {
+		return output.NewTemplateFormatter(tmpl, loc)
+	}
+	if tmpl != "" {
+		return nil, fmt.Errorf("--template requires --output template")
+	}
+	return output.NewFormatter(format, loc), nil
+}
+
+// openOutput 打开 --output-file 指定的结果文件（已存在时覆盖），为空时为命令的标准输出
+// 返回的 closeFn 关闭结果文件，写入失败时返回错误
+func openOutput(cmd *cobra.Command, path string) (w io.Writer, closeFn func() error, err error) {
+	if path == "" {
+		return cmd.OutOrStdout(), func() error { return nil }, nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return file, file.Close, nil
+}
+
+// parseTimezone 解析 --timezone 参数，为空时返回 nil
+func parseTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %w", err)
+	}
+	return loc, nil
+}
+
+// penaltyFlags 宽限时间和扣分档位参数，check 和 batch 共用
+type penaltyFlags struct {
+	grace time.Duration
+	tiers []string
+}
+
+// register 注册宽限时间和扣分档位参数
+func (f *penaltyFlags) register(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&f.grace, "grace", 0, "Grace period after the deadline during which pushes count as on time, e.g. 15m")
+	cmd.Flags().StringArrayVar(&f.tiers, "penalty", nil, "Penalty tier AFTER:POINTS[:PER_HOUR], e.g. 15m:0:5 (repeatable)")
+}
+
+// changed 返回命令行是否指定了 --grace 和 --penalty
+func (f *penaltyFlags) changed(cmd *cobra.Command) (grace, tiers bool) {
+	return cmd.Flags().Changed("grace"), cmd.Flags().Changed("penalty")
+}
+
+// policy 返回宽限时间和按 AFTER 排序的扣分档位
+func (f *penaltyFlags) policy() (models.LatenessPolicy, error) {
+	policy := models.LatenessPolicy{Grace: f.grace}
+	for _, value := range f.tiers {
+		tier, err := monitor.ParsePenaltyTier(value)
+		if err != nil {
+			return policy, fmt.Errorf("invalid --penalty: %w", err)
+		}
+		policy.Tiers = append(policy.Tiers, tier)
+	}
+	monitor.SortPenaltyTiers(policy.Tiers)
+	return policy, nil
+}
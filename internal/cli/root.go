@@ -2,15 +2,50 @@ package cli
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
 )
 
+// langAnnotation 子命令的默认语言（未指定 --lang 时使用），没有设置时为英文
+const langAnnotation = "default-lang"
+
+// lang --lang 参数
+var lang string
+
 var rootCmd = &cobra.Command{
 	Use:   "git-event-monitor",
 	Short: "Monitor Git repository code submission events",
 	Long: `A tool to monitor Git repository code submission events for code competition fairness.
 
 Supports both GitHub and Gitee platforms, checking push events and merged pull requests
-to verify code submission compliance with deadlines.`,
+to verify code submission compliance with deadlines.
+
+Durations, result descriptions, error messages, table labels and batch status
+values are shown in --lang (en or zh); JSON output and reports also carry
+language-neutral values such as time_difference_seconds and status codes.
+--lang only translates values: the column headers of CSV and Excel results
+(batch result columns, the 仓库明细 sheet, check --output csv/xlsx) stay in
+Chinese, because existing sheets are matched by these headers, and the batch
+progress log is always in English.`,
+	PersistentPreRunE: setLanguage,
+}
+
+// setLanguage 按 --lang（或子命令的默认语言）设置消息语言
+func setLanguage(cmd *cobra.Command, args []string) error {
+	name := lang
+	if name == "" {
+		name = cmd.Annotations[langAnnotation]
+	}
+	if name == "" {
+		i18n.SetLanguage(i18n.English)
+		return nil
+	}
+	parsed, err := i18n.Parse(name)
+	if err != nil {
+		return err
+	}
+	i18n.SetLanguage(parsed)
+	return nil
 }
 
 // Execute 执行根命令
@@ -19,8 +54,12 @@ func Execute() error {
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", "Language of messages and result values: en or zh (default en, zh for batch)")
+
 	// 添加子命令
 	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(batchCmd)
+	rootCmd.AddCommand(serveWebhooksCmd)
 }
 
 // 如果命令执行出错，打印使用说明
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/webhook"
+)
+
+var (
+	webhookAddr         string
+	webhookGitHubSecret string
+	webhookGiteeSecret  string
+	webhookStorePath    string
+)
+
+var serveWebhooksCmd = &cobra.Command{
+	Use:   "serve-webhooks",
+	Short: "Receive GitHub and Gitee webhooks and record push times",
+	Long: `Run an HTTP server that receives organisation-level webhooks from GitHub and Gitee.
+
+Each verified push, merged pull/merge request and tag push is stored as a unified
+event whose created_at is the time this server received it. This gives an
+independent source of push times next to the platform events API.
+
+Endpoints:
+  POST /webhooks/github   (verified with X-Hub-Signature-256)
+  POST /webhooks/gitee    (verified with the WebHook password or signing key)
+  GET  /healthz
+
+Examples:
+  git-event-monitor serve-webhooks --github-secret s3cr3t
+  git-event-monitor serve-webhooks --addr :9000 --gitee-secret s3cr3t --store events.jsonl`,
+	Args: cobra.NoArgs,
+	RunE: runServeWebhooks,
+}
+
+func init() {
+	serveWebhooksCmd.Flags().StringVar(&webhookAddr, "addr", ":8080", "Address to listen on")
+	serveWebhooksCmd.Flags().StringVar(&webhookGitHubSecret, "github-secret", "", "GitHub webhook secret (enables /webhooks/github)")
+	serveWebhooksCmd.Flags().StringVar(&webhookGiteeSecret, "gitee-secret", "", "Gitee webhook password or signing key (enables /webhooks/gitee)")
+	serveWebhooksCmd.Flags().StringVar(&webhookStorePath, "store", "webhook-events.jsonl", "JSONL file to append received events to")
+}
+
+func runServeWebhooks(cmd *cobra.Command, args []string) error {
+	if webhookGitHubSecret == "" && webhookGiteeSecret == "" {
+		return fmt.Errorf("at least one of --github-secret or --gitee-secret is required")
+	}
+
+	store, err := webhook.NewFileStore(webhookStorePath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	server := &http.Server{
+		Addr: webhookAddr,
+		Handler: webhook.NewServer(webhook.Config{
+			GitHubSecret: webhookGitHubSecret,
+			GiteeSecret:  webhookGiteeSecret,
+			Log:          cmd.OutOrStdout(),
+		}, store),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	fmt.Fprintf(cmd.OutOrStdout(), "🚀 Listening on %s, storing events in %s\n", webhookAddr, webhookStorePath)
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
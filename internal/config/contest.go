@@ -0,0 +1,282 @@
+// Package config 读取比赛配置文件（赛道、截止时间和参赛者）
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
+	"github.com/luoliwoshang/git-event-monitor/internal/repourl"
+)
+
+// Contest 比赛配置
+type Contest struct {
+	Name string `yaml:"name" json:"name"`
+	// DefaultTrack 参赛者未指定赛道（或不在参赛者名单中）时使用的赛道；只有一个赛道时可以省略
+	DefaultTrack string        `yaml:"default_track" json:"default_track"`
+	Tracks       []*Track      `yaml:"tracks" json:"tracks"`
+	Participants []Participant `yaml:"participants" json:"participants"`
+}
+
+// Track 赛道规则
+type Track struct {
+	Name string `yaml:"name" json:"name"`
+	// Deadline 截止时间，RFC3339 或不带时区的 "2006-01-02 15:04[:05]" 等写法（按 Timezone 解析）
+	Deadline string `yaml:"deadline" json:"deadline"`
+	// Timezone IANA 时区名（如 Asia/Shanghai），为空时为 UTC
+	Timezone string `yaml:"timezone" json:"timezone"`
+	// Grace 截止后的宽限时间（如 15m），宽限内的推送视为准时
+	Grace string `yaml:"grace" json:"grace"`
+	// Penalties 超过宽限时间后的扣分档位
+	Penalties []Penalty `yaml:"penalties" json:"penalties"`
+	// Branches 计入提交的分支，支持通配符（如 release/*），为空时不限分支
+	Branches []string `yaml:"branches" json:"branches"`
+	// Start 比赛开始时间，写法与 Deadline 相同，为空时不检查
+	Start string `yaml:"start" json:"start"`
+	// CommitsBeforeStart 开始前推送的提交数达到该值时标记 pushed_before_start，为 0 时为 monitor.DefaultCommitsBeforeStart
+	CommitsBeforeStart int `yaml:"commits_before_start" json:"commits_before_start"`
+
+	location *time.Location
+	deadline time.Time
+	start    time.Time
+	policy   models.LatenessPolicy
+}
+
+// Penalty 扣分档位：超过截止时间 after 之后，扣 points 分，另外每小时扣 per_hour 分
+type Penalty struct {
+	// Name 档位名称，为空时为 "+<after>"
+	Name    string  `yaml:"name" json:"name"`
+	After   string  `yaml:"after" json:"after"`
+	Points  float64 `yaml:"points" json:"points"`
+	PerHour float64 `yaml:"per_hour" json:"per_hour"`
+}
+
+// Participant 参赛者
+type Participant struct {
+	Name  string   `yaml:"name" json:"name"`
+	Team  string   `yaml:"team" json:"team"`
+	Repos []string `yaml:"repos" json:"repos"`
+	// Track 赛道名称，为空时使用 DefaultTrack
+	Track string `yaml:"track" json:"track"`
+
+	repos []repourl.Repository
+}
+
+// Load 读取比赛配置文件（.json 按 JSON 解析，其余按 YAML 解析），并检查赛道和参赛者
+func Load(path string) (*Contest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("无法读取比赛配置: %w", err)
+	}
+
+	contest := &Contest{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, contest)
+	} else {
+		err = yaml.Unmarshal(data, contest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("比赛配置解析失败: %w", err)
+	}
+
+	if err := contest.init(); err != nil {
+		return nil, fmt.Errorf("比赛配置无效: %w", err)
+	}
+	return contest, nil
+}
+
+// init 解析赛道的时间规则和参赛者的仓库地址
+func (c *Contest) init() error {
+	if len(c.Tracks) == 0 {
+		return fmt.Errorf("至少需要一个赛道")
+	}
+
+	names := make(map[string]bool)
+	for i, track := range c.Tracks {
+		if track.Name == "" {
+			return fmt.Errorf("第%d个赛道缺少 name", i+1)
+		}
+		if names[track.Name] {
+			return fmt.Errorf("赛道 %s 重复", track.Name)
+		}
+		names[track.Name] = true
+		if err := track.init(); err != nil {
+			return fmt.Errorf("赛道 %s: %w", track.Name, err)
+		}
+	}
+	if c.DefaultTrack == "" && len(c.Tracks) == 1 {
+		c.DefaultTrack = c.Tracks[0].Name
+	}
+	if c.DefaultTrack != "" && !names[c.DefaultTrack] {
+		return fmt.Errorf("默认赛道 %s 不存在", c.DefaultTrack)
+	}
+
+	for i := range c.Participants {
+		participant := &c.Participants[i]
+		if participant.Track != "" && !names[participant.Track] {
+			return fmt.Errorf("参赛者 %s 的赛道 %s 不存在", participant.Name, participant.Track)
+		}
+		for _, raw := range participant.Repos {
+			repo, err := repourl.Parse(raw)
+			if err != nil {
+				return fmt.Errorf("参赛者 %s 的仓库地址 %s: %w", participant.Name, raw, err)
+			}
+			participant.repos = append(participant.repos, repo)
+		}
+	}
+	return nil
+}
+
+// init 解析时区、截止时间、开始时间、宽限时间和扣分档位
+func (t *Track) init() error {
+	t.location = time.UTC
+	if t.Timezone != "" {
+		loc, err := time.LoadLocation(t.Timezone)
+		if err != nil {
+			return fmt.Errorf("时区无效: %w", err)
+		}
+		t.location = loc
+	}
+
+	if t.Deadline == "" {
+		return fmt.Errorf("缺少 deadline")
+	}
+	var err error
+	if t.deadline, err = t.parseTime(t.Deadline); err != nil {
+		return fmt.Errorf("deadline 无效: %w", err)
+	}
+	if t.Start != "" {
+		if t.start, err = t.parseTime(t.Start); err != nil {
+			return fmt.Errorf("start 无效: %w", err)
+		}
+		if !t.start.Before(t.deadline) {
+			return fmt.Errorf("start 必须早于 deadline")
+		}
+	}
+	if t.CommitsBeforeStart < 0 {
+		return fmt.Errorf("commits_before_start 无效: %d", t.CommitsBeforeStart)
+	}
+	if t.Grace != "" {
+		if t.policy.Grace, err = time.ParseDuration(t.Grace); err != nil || t.policy.Grace < 0 {
+			return fmt.Errorf("grace 无效: %s", t.Grace)
+		}
+	}
+	for _, penalty := range t.Penalties {
+		after, err := time.ParseDuration(penalty.After)
+		if err != nil || after < 0 {
+			return fmt.Errorf("扣分档位的 after 无效: %s", penalty.After)
+		}
+		name := penalty.Name
+		if name == "" {
+			name = "+" + penalty.After
+		}
+		t.policy.Tiers = append(t.policy.Tiers, models.PenaltyTier{Name: name, After: after, Points: penalty.Points, PerHour: penalty.PerHour})
+	}
+	monitor.SortPenaltyTiers(t.policy.Tiers)
+	return nil
+}
+
+// parseTime 解析 RFC3339 时间，不带时区的写法按赛道时区解析
+func (t *Track) parseTime(value string) (time.Time, error) {
+	return monitor.ParseTime(value, t.location)
+}
+
+// Location 赛道时区
+func (t *Track) Location() *time.Location {
+	return t.location
+}
+
+// DeadlineTime 截止时间（不含宽限时间）
+func (t *Track) DeadlineTime() time.Time {
+	return t.deadline
+}
+
+// FormatDeadline 按赛道时区返回 RFC3339 格式的截止时间（不含宽限时间）
+func (t *Track) FormatDeadline() string {
+	return t.deadline.In(t.location).Format(time.RFC3339)
+}
+
+// Policy 宽限时间和扣分档位
+func (t *Track) Policy() models.LatenessPolicy {
+	return t.policy
+}
+
+// MergePolicy 用命令行的宽限时间和扣分档位覆盖赛道的设置
+// graceSet、tiersSet 表示命令行是否指定了宽限时间和扣分档位，只覆盖指定的部分：
+// 只给宽限时间时保留赛道的扣分档位，只给扣分档位时保留赛道的宽限时间
+func (t *Track) MergePolicy(cli models.LatenessPolicy, graceSet, tiersSet bool) models.LatenessPolicy {
+	policy := t.policy
+	if graceSet {
+		policy.Grace = cli.Grace
+	}
+	if tiersSet {
+		policy.Tiers = cli.Tiers
+	}
+	return policy
+}
+
+// StartTime 比赛开始时间，未设置时为零值
+func (t *Track) StartTime() time.Time {
+	return t.start
+}
+
+// HasPenalties 是否有赛道设置了扣分档位
+func (c *Contest) HasPenalties() bool {
+	for _, track := range c.Tracks {
+		if len(track.policy.Tiers) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Track 按名称查找赛道
+func (c *Contest) Track(name string) *Track {
+	for _, track := range c.Tracks {
+		if track.Name == name {
+			return track
+		}
+	}
+	return nil
+}
+
+// TrackFor 参赛者适用的赛道；participant 为 nil 或未指定赛道时为默认赛道，没有默认赛道时返回 nil
+func (c *Contest) TrackFor(participant *Participant) *Track {
+	if participant != nil && participant.Track != "" {
+		return c.Track(participant.Track)
+	}
+	return c.Track(c.DefaultTrack)
+}
+
+// FindByRepository 按仓库查找参赛者（owner/repo 不区分大小写），找不到时返回 nil
+func (c *Contest) FindByRepository(p models.Platform, fullName string) *Participant {
+	for i := range c.Participants {
+		for _, repo := range c.Participants[i].repos {
+			if repo.Platform == p && strings.EqualFold(repo.FullName(), fullName) {
+				return &c.Participants[i]
+			}
+		}
+	}
+	return nil
+}
+
+// FindByName 按姓名查找参赛者，找不到时返回 nil
+func (c *Contest) FindByName(name string) *Participant {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	for i := range c.Participants {
+		if c.Participants[i].Name == name {
+			return &c.Participants[i]
+		}
+	}
+	return nil
+}
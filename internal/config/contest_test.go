@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// writeContest 把配置写入临时文件并返回路径
+func writeContest(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write contest file: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeContest(t, "contest.yaml", `
+name: Hackathon
+default_track: web
+tracks:
+  - name: web
+    deadline: "2025-09-30 23:59"
+    timezone: Asia/Shanghai
+    grace: 15m
+    penalties:
+      - {name: very late, after: 24h, points: 100}
+      - {after: 15m, per_hour: 5}
+    start: "2025-09-01 09:00"
+    commits_before_start: 10
+    branches: [main, "release/*"]
+  - name: ai
+    deadline: "2025-10-07T18:00:00Z"
+participants:
+  - name: 张三
+    team: 队伍A
+    repos: [https://github.com/Team-A/project, gitee.com/team-a/docs]
+    track: ai
+  - name: 李四
+    repos: [git@github.com:team-b/app.git]
+`)
+
+	contest, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	web := contest.Track("web")
+	if web == nil {
+		t.Fatal("Expected web track")
+	}
+	wantDeadline := time.Date(2025, 9, 30, 15, 59, 0, 0, time.UTC)
+	if !web.DeadlineTime().Equal(wantDeadline) {
+		t.Errorf("Expected deadline %v, got %v", wantDeadline, web.DeadlineTime())
+	}
+	if web.Policy().Grace != 15*time.Minute || web.FormatDeadline() != "2025-09-30T23:59:00+08:00" {
+		t.Errorf("Unexpected grace %v / deadline %s", web.Policy().Grace, web.FormatDeadline())
+	}
+	tiers := web.Policy().Tiers
+	if len(tiers) != 2 || tiers[0].Name != "+15m" || tiers[0].PerHour != 5 || tiers[1].Name != "very late" || tiers[1].After != 24*time.Hour {
+		t.Errorf("Unexpected penalty tiers: %+v", tiers)
+	}
+	if !contest.HasPenalties() {
+		t.Error("Expected contest to have penalties")
+	}
+	if !web.StartTime().Equal(time.Date(2025, 9, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected start: %v", web.StartTime())
+	}
+	if web.CommitsBeforeStart != 10 {
+		t.Errorf("Expected commits_before_start 10, got %d", web.CommitsBeforeStart)
+	}
+
+	zhang := contest.FindByRepository(models.PlatformGitHub, "team-a/project")
+	if zhang == nil || zhang.Name != "张三" || contest.TrackFor(zhang).Name != "ai" {
+		t.Errorf("Expected 张三 on the ai track, got %+v", zhang)
+	}
+	if p := contest.FindByRepository(models.PlatformGitee, "team-a/docs"); p != zhang {
+		t.Errorf("Expected gitee repository to match 张三, got %+v", p)
+	}
+	li := contest.FindByName("李四")
+	if li == nil || contest.TrackFor(li).Name != "web" {
+		t.Errorf("Expected 李四 on the default track, got %+v", li)
+	}
+	if contest.FindByRepository(models.PlatformGitHub, "someone/else") != nil {
+		t.Error("Expected unknown repository not to match")
+	}
+	if contest.TrackFor(nil).Name != "web" {
+		t.Error("Expected default track for unlisted repositories")
+	}
+}
+
+func TestLoad_SingleTrackDefault(t *testing.T) {
+	path := writeContest(t, "contest.json", `{"tracks": [{"name": "main", "deadline": "2025-09-30T23:59:59+08:00"}]}`)
+
+	contest, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if track := contest.TrackFor(nil); track == nil || track.Name != "main" {
+		t.Errorf("Expected the only track to be the default, got %+v", track)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"no tracks", `name: x`, "至少需要一个赛道"},
+		{"bad deadline", "tracks:\n  - name: a\n    deadline: tomorrow", "deadline 无效"},
+		{"bad timezone", "tracks:\n  - name: a\n    deadline: 2025-09-30 23:59\n    timezone: Mars/Base", "时区无效"},
+		{"bad penalty", "tracks:\n  - name: a\n    deadline: 2025-09-30T23:59:59Z\n    penalties: [{after: later}]", "after 无效"},
+		{"bad grace", "tracks:\n  - name: a\n    deadline: 2025-09-30T23:59:59Z\n    grace: soon", "grace 无效"},
+		{"negative commits before start", "tracks:\n  - name: a\n    deadline: 2025-09-30T23:59:59Z\n    commits_before_start: -1", "commits_before_start 无效"},
+		{"start after deadline", "tracks:\n  - name: a\n    deadline: 2025-09-30T23:59:59Z\n    start: 2025-10-01T00:00:00Z", "start 必须早于 deadline"},
+		{"duplicate track", "tracks:\n  - name: a\n    deadline: 2025-09-30T23:59:59Z\n  - name: a\n    deadline: 2025-09-30T23:59:59Z", "重复"},
+		{"unknown track", "tracks:\n  - name: a\n    deadline: 2025-09-30T23:59:59Z\nparticipants:\n  - name: x\n    track: b", "赛道 b 不存在"},
+		{"bad repository", "tracks:\n  - name: a\n    deadline: 2025-09-30T23:59:59Z\nparticipants:\n  - name: x\n    repos: [https://gitlab.com/a/b]", "仓库地址"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeContest(t, "contest.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package i18n 面向用户的文字（时间差、结果描述、错误信息、批量处理的状态值）的中英文消息表
+// 结构化数据（JSON、报告、断点记录）只保存与语言无关的值，显示时再按语言转换为文字
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Lang 消息语言
+type Lang string
+
+// 支持的语言
+const (
+	English Lang = "en"
+	Chinese Lang = "zh"
+)
+
+// Supported 支持的语言列表
+var Supported = []Lang{English, Chinese}
+
+// current 当前语言，在程序启动时通过 SetLanguage 设置
+var current = English
+
+// Parse 解析语言名称（en、zh，不区分大小写，也接受 zh-CN、en_US 这类写法）
+func Parse(name string) (Lang, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, lang := range Supported {
+		if name == string(lang) || strings.HasPrefix(name, string(lang)+"-") || strings.HasPrefix(name, string(lang)+"_") {
+			return lang, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported language: %s (supported: en, zh)", name)
+}
+
+// SetLanguage 设置当前语言
+func SetLanguage(lang Lang) {
+	current = lang
+}
+
+// Language 当前语言
+func Language() Lang {
+	return current
+}
+
+// T 按当前语言返回消息
+func T(key string, args ...interface{}) string {
+	return current.T(key, args...)
+}
+
+// T 按语言返回消息，缺少该语言时使用英文，消息不存在时返回 key
+func (l Lang) T(key string, args ...interface{}) string {
+	texts, ok := messages[key]
+	if !ok {
+		return key
+	}
+	text, ok := texts[l]
+	if !ok {
+		text = texts[English]
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// FormatDuration 按当前语言格式化持续时间，如 "3 hours 20 minutes"、"3小时20分钟"
+func FormatDuration(d time.Duration) string {
+	return current.FormatDuration(d)
+}
+
+// FormatDuration 按语言格式化持续时间（不足一分钟的部分舍去）
+func (l Lang) FormatDuration(d time.Duration) string {
+	if d < time.Hour {
+		return l.T(MsgMinutes, int(d.Minutes()))
+	}
+	if d < 24*time.Hour {
+		hours := int(d.Hours())
+		minutes := int(d.Minutes()) % 60
+		if minutes > 0 {
+			return l.T(MsgHoursMinutes, hours, minutes)
+		}
+		return l.T(MsgHours, hours)
+	}
+
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	if hours > 0 {
+		return l.T(MsgDaysHours, days, hours)
+	}
+	return l.T(MsgDays, days)
+}
+
+// TimeDifference 按当前语言描述提交时间与截止时间的差，seconds 为晚于截止时间的秒数（早于时为负数）
+func TimeDifference(seconds int64) string {
+	return current.TimeDifference(seconds)
+}
+
+// TimeDifference 按语言描述提交时间与截止时间的差，如 "3 hours before deadline"、"超过截止时间 3小时"
+func (l Lang) TimeDifference(seconds int64) string {
+	d := time.Duration(seconds) * time.Second
+	if d < 0 {
+		return l.T(MsgBeforeDeadline, l.FormatDuration(-d))
+	}
+	return l.T(MsgAfterDeadline, l.FormatDuration(d))
+}
@@ -0,0 +1,71 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]Lang{"en": English, "ZH": Chinese, "zh-CN": Chinese, "en_US": English}
+	for name, want := range tests {
+		if got, err := Parse(name); err != nil || got != want {
+			t.Errorf("Parse(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := Parse("fr"); err == nil {
+		t.Error("Expected error for unsupported language")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d      time.Duration
+		en, zh string
+	}{
+		{45 * time.Minute, "45 minutes", "45分钟"},
+		{3 * time.Hour, "3 hours", "3小时"},
+		{3*time.Hour + 20*time.Minute, "3 hours 20 minutes", "3小时20分钟"},
+		{48 * time.Hour, "2 days", "2天"},
+		{50 * time.Hour, "2 days 2 hours", "2天2小时"},
+	}
+	for _, tt := range tests {
+		if got := English.FormatDuration(tt.d); got != tt.en {
+			t.Errorf("English %v: got %q, want %q", tt.d, got, tt.en)
+		}
+		if got := Chinese.FormatDuration(tt.d); got != tt.zh {
+			t.Errorf("Chinese %v: got %q, want %q", tt.d, got, tt.zh)
+		}
+	}
+}
+
+func TestTimeDifference(t *testing.T) {
+	if got := English.TimeDifference(-3 * 3600); got != "3 hours before deadline" {
+		t.Errorf("Unexpected English time difference: %q", got)
+	}
+	if got := Chinese.TimeDifference(600); got != "超过截止时间 10分钟" {
+		t.Errorf("Unexpected Chinese time difference: %q", got)
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := Chinese.T(MsgNoCodeEvents, 30); got != "在最近的 30 个仓库事件中未找到代码提交事件" {
+		t.Errorf("Unexpected message: %q", got)
+	}
+	if got := Lang("fr").T(StatusPrefix + "late"); got != "Late" {
+		t.Errorf("Expected English fallback, got %q", got)
+	}
+	if got := English.T("missing.key"); got != "missing.key" {
+		t.Errorf("Expected unknown key to be returned as is, got %q", got)
+	}
+}
+
+// 每条消息都要有中英文
+func TestMessagesComplete(t *testing.T) {
+	for key, texts := range messages {
+		for _, lang := range Supported {
+			if texts[lang] == "" {
+				t.Errorf("%s: missing %s text", key, lang)
+			}
+		}
+	}
+}
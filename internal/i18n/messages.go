@@ -0,0 +1,226 @@
+package i18n
+
+// 持续时间
+const (
+	MsgMinutes      = "duration.minutes"
+	MsgHours        = "duration.hours"
+	MsgHoursMinutes = "duration.hours_minutes"
+	MsgDays         = "duration.days"
+	MsgDaysHours    = "duration.days_hours"
+)
+
+// 时间差和结果描述
+const (
+	MsgBeforeDeadline = "time_difference.before"
+	MsgAfterDeadline  = "time_difference.after"
+	MsgLatestEvent    = "description.latest_event"
+	MsgNewestCommit   = "description.newest_commit"
+)
+
+// 依据说明
+const (
+	MsgEvidenceEvent       = "evidence.event"
+	MsgEvidenceEventBranch = "evidence.event_branch"
+	MsgEvidenceLatest      = "evidence.latest"
+	MsgEvidenceBefore      = "evidence.last_before_deadline"
+	MsgEvidenceCommit      = "evidence.commit"
+	MsgEvidencePushedAt    = "evidence.pushed_at"
+	MsgEvidenceWebhook     = "evidence.webhook"
+)
+
+// 分析结果中的错误信息
+const (
+	MsgNoCodeEvents     = "error.no_code_events"
+	MsgInvalidStart     = "error.invalid_start"
+	MsgInvalidDeadline  = "error.invalid_deadline"
+	MsgInvalidEventTime = "error.invalid_event_time"
+)
+
+// 报告（markdown、html）中的文字
+const (
+	MsgReportCheckTitle     = "report.check_title"
+	MsgReportSummaryTitle   = "report.summary_title"
+	MsgReportCounts         = "report.counts"
+	MsgReportVerdict        = "report.verdict"
+	MsgReportTimeline       = "report.timeline"
+	MsgReportTime           = "report.time"
+	MsgReportEvent          = "report.event"
+	MsgReportLink           = "report.link"
+	MsgReportRepository     = "report.repository"
+	MsgReportRow            = "report.row"
+	MsgReportName           = "report.name"
+	MsgReportTeam           = "report.team"
+	MsgReportTrack          = "report.track"
+	MsgReportLastPush       = "report.last_push"
+	MsgReportTimeDifference = "report.time_difference"
+	MsgReportPenalty        = "report.penalty"
+	MsgReportFlags          = "report.flags"
+	MsgReportNoEvents       = "report.no_events"
+	MsgReportView           = "report.view"
+	MsgTimelineCreated      = "timeline.created"
+	MsgTimelineEarliest     = "timeline.earliest_push"
+	MsgTimelineDeadline     = "timeline.deadline"
+)
+
+// 表格输出（check 默认的 table 格式）中的文字
+const (
+	MsgTableNoEvents       = "table.no_events"
+	MsgTableFound          = "table.found"
+	MsgTableEventsChecked  = "table.events_checked"
+	MsgTableError          = "table.error"
+	MsgTableTimeDifference = "table.time_difference"
+	MsgTableEarliest       = "table.earliest"
+	MsgTableBeforeStart    = "table.before_start"
+	MsgTableOnTime         = "table.on_time"
+	MsgTableLate           = "table.late"
+	MsgTableLowConfidence  = "table.low_confidence"
+	MsgTableLateness       = "table.lateness"
+	MsgTableLastEvent      = "table.last_event"
+	MsgTableBranchCommits  = "table.branch_commits"
+	MsgTableVerdict        = "table.verdict"
+	MsgTableConfidence     = "table.confidence"
+	MsgTableRepository     = "table.repository"
+	MsgTableFlags          = "table.flags"
+	MsgTableField          = "table.field"
+	MsgTableValue          = "table.value"
+	MsgTableEventID        = "table.event_id"
+	MsgTableEventType      = "table.event_type"
+	MsgTableCreatedAt      = "table.created_at"
+	MsgTableActor          = "table.actor"
+	MsgTableRepo           = "table.repo"
+	MsgTableBranch         = "table.branch"
+	MsgTableBeforeDeadline = "table.before_deadline"
+	MsgTableAfterDeadline  = "table.after_deadline"
+	MsgTableSource         = "table.source"
+	MsgTableTimestamp      = "table.timestamp"
+	MsgTableDescription    = "table.description"
+	MsgTableURL            = "table.url"
+	MsgTableVisibility     = "table.visibility"
+	MsgTablePushedAt       = "table.pushed_at"
+	MsgTableDefaultBranch  = "table.default_branch"
+	MsgTableForkOf         = "table.fork_of"
+	MsgTableArchived       = "table.archived"
+	MsgTableSize           = "table.size"
+	MsgTableLicense        = "table.license"
+)
+
+// VerdictPrefix、ConfidencePrefix 结论和可信度的消息前缀，key 为前缀 + 值（见 models.Verdict*、models.Confidence*）
+const (
+	VerdictPrefix    = "verdict."
+	ConfidencePrefix = "confidence."
+)
+
+// StatusPrefix 批量处理状态值的消息前缀，key 为 StatusPrefix + 状态值
+const StatusPrefix = "status."
+
+// messages 消息表：key -> 语言 -> 文字（fmt 格式）
+var messages = map[string]map[Lang]string{
+	MsgMinutes:      {English: "%d minutes", Chinese: "%d分钟"},
+	MsgHours:        {English: "%d hours", Chinese: "%d小时"},
+	MsgHoursMinutes: {English: "%d hours %d minutes", Chinese: "%d小时%d分钟"},
+	MsgDays:         {English: "%d days", Chinese: "%d天"},
+	MsgDaysHours:    {English: "%d days %d hours", Chinese: "%d天%d小时"},
+
+	MsgBeforeDeadline: {English: "%s before deadline", Chinese: "截止时间前 %s"},
+	MsgAfterDeadline:  {English: "%s after deadline", Chinese: "超过截止时间 %s"},
+	MsgLatestEvent:    {English: "Latest %s (%s)", Chinese: "最近的 %s (%s)"},
+	MsgNewestCommit:   {English: "Newest commit %s on %s (%s)", Chinese: "最新提交 %s，分支 %s (%s)"},
+
+	MsgEvidenceEvent:       {English: "%s by %s (%s)", Chinese: "%s，推送者 %s（%s）"},
+	MsgEvidenceEventBranch: {English: "%s by %s on %s (%s)", Chinese: "%s，推送者 %s，分支 %s（%s）"},
+	MsgEvidenceLatest:      {English: "latest", Chinese: "最近"},
+	MsgEvidenceBefore:      {English: "last before deadline", Chinese: "截止前最后"},
+	MsgEvidenceCommit:      {English: "Commit %s on %s", Chinese: "提交 %s，分支 %s"},
+	MsgEvidencePushedAt:    {English: "Last push to any branch", Chinese: "任一分支的最后推送"},
+	MsgEvidenceWebhook:     {English: "Webhook push by %s on %s (%s)", Chinese: "Webhook 推送，推送者 %s，分支 %s（%s）"},
+
+	MsgNoCodeEvents:     {English: "No code submission events found in the last %d repository events", Chinese: "在最近的 %d 个仓库事件中未找到代码提交事件"},
+	MsgInvalidStart:     {English: "Invalid start time format: %s", Chinese: "开始时间格式错误: %s"},
+	MsgInvalidDeadline:  {English: "Invalid deadline format: %s", Chinese: "截止时间格式错误: %s"},
+	MsgInvalidEventTime: {English: "Invalid event time format: %s", Chinese: "事件时间格式错误: %s"},
+
+	MsgReportCheckTitle:     {English: "Submission check: %s", Chinese: "提交检查：%s"},
+	MsgReportSummaryTitle:   {English: "Submission summary", Chinese: "提交情况汇总"},
+	MsgReportCounts:         {English: "%d repositories: %d on time, %d late, %d other", Chinese: "共 %d 个仓库：准时 %d，超时 %d，其他 %d"},
+	MsgReportVerdict:        {English: "Verdict", Chinese: "结论"},
+	MsgReportTimeline:       {English: "Timeline", Chinese: "时间线"},
+	MsgReportTime:           {English: "Time", Chinese: "时间"},
+	MsgReportEvent:          {English: "Event", Chinese: "事件"},
+	MsgReportLink:           {English: "Link", Chinese: "链接"},
+	MsgReportRepository:     {English: "Repository", Chinese: "仓库"},
+	MsgReportRow:            {English: "Row", Chinese: "行号"},
+	MsgReportName:           {English: "Name", Chinese: "姓名"},
+	MsgReportTeam:           {English: "Team", Chinese: "队伍"},
+	MsgReportTrack:          {English: "Track", Chinese: "赛道"},
+	MsgReportLastPush:       {English: "Last push", Chinese: "最后推送"},
+	MsgReportTimeDifference: {English: "Time difference", Chinese: "时间差"},
+	MsgReportPenalty:        {English: "Penalty", Chinese: "扣分"},
+	MsgReportFlags:          {English: "Flags", Chinese: "标记"},
+	MsgReportNoEvents:       {English: "No events", Chinese: "没有事件"},
+	MsgReportView:           {English: "view", Chinese: "查看"},
+	MsgTimelineCreated:      {English: "Repository created", Chinese: "仓库创建"},
+	MsgTimelineEarliest:     {English: "Earliest push by %s", Chinese: "最早推送，推送者 %s"},
+	MsgTimelineDeadline:     {English: "Deadline", Chinese: "截止时间"},
+
+	MsgTableNoEvents:       {English: "No code events found", Chinese: "未找到代码提交事件"},
+	MsgTableFound:          {English: "Code event found", Chinese: "找到代码提交事件"},
+	MsgTableEventsChecked:  {English: "Events checked: %d", Chinese: "检查事件数：%d"},
+	MsgTableError:          {English: "Error: %s", Chinese: "错误：%s"},
+	MsgTableTimeDifference: {English: "Time difference: %s", Chinese: "时间差：%s"},
+	MsgTableEarliest:       {English: "Earliest code event: %s (%s)", Chinese: "最早的代码事件：%s（%s）"},
+	MsgTableBeforeStart:    {English: "%d pushes (%d commits) before the contest start", Chinese: "比赛开始前推送 %d 次（%d 个提交）"},
+	MsgTableOnTime:         {English: "Status: ✅ Submitted before deadline", Chinese: "状态：✅ 截止前提交"},
+	MsgTableLate:           {English: "Status: ❌ Submitted after deadline", Chinese: "状态：❌ 截止后提交"},
+	MsgTableLowConfidence:  {English: "Low confidence: judged by commit dates, which can be forged", Chinese: "可信度低：依据提交时间判断，提交时间可以被伪造"},
+	MsgTableLateness:       {English: "Lateness tier: %s, penalty: %s", Chinese: "迟交档位：%s，扣分：%s"},
+	MsgTableLastEvent:      {English: "Last Code Event Details:", Chinese: "最近代码事件详情："},
+	MsgTableBranchCommits:  {English: "Branch Commits:", Chinese: "各分支提交："},
+	MsgTableVerdict:        {English: "Verdict: %s", Chinese: "结论：%s"},
+	MsgTableConfidence:     {English: " (confidence: %s)", Chinese: "（可信度：%s）"},
+	MsgTableRepository:     {English: "Repository Details:", Chinese: "仓库信息："},
+	MsgTableFlags:          {English: "Flags: %s", Chinese: "标记：%s"},
+	MsgTableField:          {English: "Field", Chinese: "字段"},
+	MsgTableValue:          {English: "Value", Chinese: "值"},
+	MsgTableEventID:        {English: "Event ID", Chinese: "事件 ID"},
+	MsgTableEventType:      {English: "Event Type", Chinese: "事件类型"},
+	MsgTableCreatedAt:      {English: "Created At", Chinese: "创建时间"},
+	MsgTableActor:          {English: "Actor", Chinese: "推送者"},
+	MsgTableRepo:           {English: "Repository", Chinese: "仓库"},
+	MsgTableBranch:         {English: "Branch", Chinese: "分支"},
+	MsgTableBeforeDeadline: {English: "Before Deadline", Chinese: "截止前"},
+	MsgTableAfterDeadline:  {English: "After Deadline", Chinese: "截止后"},
+	MsgTableSource:         {English: "Source", Chinese: "来源"},
+	MsgTableTimestamp:      {English: "Timestamp", Chinese: "时间"},
+	MsgTableDescription:    {English: "Description", Chinese: "说明"},
+	MsgTableURL:            {English: "URL", Chinese: "链接"},
+	MsgTableVisibility:     {English: "Visibility", Chinese: "可见性"},
+	MsgTablePushedAt:       {English: "Pushed At", Chinese: "最后推送时间"},
+	MsgTableDefaultBranch:  {English: "Default Branch", Chinese: "默认分支"},
+	MsgTableForkOf:         {English: "Fork Of", Chinese: "Fork 自"},
+	MsgTableArchived:       {English: "Archived", Chinese: "已归档"},
+	MsgTableSize:           {English: "Size", Chinese: "大小"},
+	MsgTableLicense:        {English: "License", Chinese: "许可证"},
+
+	VerdictPrefix + "on_time":      {English: "On time", Chinese: "准时"},
+	VerdictPrefix + "late":         {English: "Late", Chinese: "超时"},
+	VerdictPrefix + "unknown":      {English: "Unknown", Chinese: "无法确定"},
+	VerdictPrefix + "empty":        {English: "Empty", Chinese: "空仓库"},
+	VerdictPrefix + "inaccessible": {English: "Inaccessible", Chinese: "不可访问"},
+	ConfidencePrefix + "high":      {English: "high confidence", Chinese: "高可信度"},
+	ConfidencePrefix + "medium":    {English: "medium confidence", Chinese: "中可信度"},
+	ConfidencePrefix + "low":       {English: "low confidence", Chinese: "低可信度"},
+
+	StatusPrefix + "accessible":                {English: "Accessible", Chinese: "可访问"},
+	StatusPrefix + "inaccessible":              {English: "Inaccessible", Chinese: "不可访问"},
+	StatusPrefix + "no_deadline":               {English: "No deadline", Chinese: "未设置截止时间"},
+	StatusPrefix + "analysis_failed":           {English: "Analysis failed", Chinese: "分析失败"},
+	StatusPrefix + "initial_commit":            {English: "Initial commit (commit time not checked)", Chinese: "初始提交（无法检查提交时间）"},
+	StatusPrefix + "empty_repository":          {English: "Empty repository (commit time not checked)", Chinese: "空仓库（无法检查提交时间）"},
+	StatusPrefix + "undetermined":              {English: "Undetermined", Chinese: "无法确定"},
+	StatusPrefix + "on_time":                   {English: "On time", Chinese: "准时提交"},
+	StatusPrefix + "late":                      {English: "Late", Chinese: "超时提交"},
+	StatusPrefix + "on_time_medium_confidence": {English: "On time (medium confidence)", Chinese: "准时提交（可信度中）"},
+	StatusPrefix + "late_medium_confidence":    {English: "Late (medium confidence)", Chinese: "超时提交（可信度中）"},
+	StatusPrefix + "on_time_low_confidence":    {English: "On time (by commit date, low confidence)", Chinese: "准时提交（依据提交时间，可信度低）"},
+	StatusPrefix + "late_low_confidence":       {English: "Late (by commit date, low confidence)", Chinese: "超时提交（依据提交时间，可信度低）"},
+}
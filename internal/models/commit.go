@@ -0,0 +1,20 @@
+package models
+
+// Commit 单个提交
+type Commit struct {
+	SHA     string `json:"sha"`
+	Author  string `json:"author,omitempty"`
+	Message string `json:"message,omitempty"`
+	// CommittedAt 提交者时间（RFC3339），由提交者本地设置，可以被伪造
+	CommittedAt string `json:"committed_at"`
+	// URL 提交的网页地址
+	URL string `json:"url,omitempty"`
+}
+
+// BranchCommits 分支上截止时间前后最新的提交
+type BranchCommits struct {
+	Branch string `json:"branch"`
+	// Before 截止时间前（含）最新的提交，After 截止时间后最新的提交，没有时为 nil
+	Before *Commit `json:"before,omitempty"`
+	After  *Commit `json:"after,omitempty"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// 迟交档位
+const (
+	// TierOnTime 截止时间前（含）提交
+	TierOnTime = "on_time"
+	// TierGrace 截止后、宽限时间内提交，视为准时
+	TierGrace = "grace"
+	// TierLate 超过宽限时间，但没有匹配的扣分档位
+	TierLate = "late"
+)
+
+// PenaltyTier 扣分档位：超过截止时间 After 之后进入该档
+// 扣分 = Points + PerHour × 超过 After 的小时数（不足一小时按一小时计）
+type PenaltyTier struct {
+	Name    string        `json:"name"`
+	After   time.Duration `json:"after"`
+	Points  float64       `json:"points,omitempty"`
+	PerHour float64       `json:"per_hour,omitempty"`
+}
+
+// LatenessPolicy 宽限时间和扣分档位（按 After 从小到大排列）
+type LatenessPolicy struct {
+	Grace time.Duration `json:"grace,omitempty"`
+	Tiers []PenaltyTier `json:"tiers,omitempty"`
+}
+
+// IsZero 没有设置宽限时间和扣分档位
+func (p LatenessPolicy) IsZero() bool {
+	return p.Grace == 0 && len(p.Tiers) == 0
+}
+
+// Lateness 最后一次提交所在的迟交档位和扣分
+type Lateness struct {
+	// Seconds 最后一次提交晚于截止时间的秒数，准时时为 0 或负数
+	Seconds int64 `json:"seconds"`
+	// Tier 档位（on_time、grace、late 或扣分档位名称）
+	Tier    string  `json:"tier"`
+	Penalty float64 `json:"penalty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+// 仓库可见性
+const (
+	VisibilityPublic   = "public"
+	VisibilityPrivate  = "private"
+	VisibilityInternal = "internal"
+)
+
+// Repository 统一的仓库信息模型
+type Repository struct {
+	FullName string `json:"full_name"`
+	// HTMLURL 仓库网页地址
+	HTMLURL string `json:"html_url,omitempty"`
+	// Visibility 可见性（public、private、internal）
+	Visibility string `json:"visibility"`
+	// Fork 是否为其他仓库的 fork，Parent 为上游仓库的 owner/repo
+	Fork   bool   `json:"fork"`
+	Parent string `json:"parent,omitempty"`
+	// CreatedAt 创建时间，PushedAt 最后推送时间（RFC3339）
+	CreatedAt     string `json:"created_at,omitempty"`
+	PushedAt      string `json:"pushed_at,omitempty"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+	Archived      bool   `json:"archived"`
+	// Size 仓库大小（KB），平台不提供时为 0
+	Size int `json:"size,omitempty"`
+	// License 许可证标识（如 MIT），没有时为空
+	License string `json:"license,omitempty"`
+}
@@ -2,15 +2,60 @@ package models
 
 // AnalysisResult 分析结果
 type AnalysisResult struct {
-	Found            bool           `json:"found"`
-	EventsChecked    int            `json:"events_checked"`
-	LastCodeEvent    *UnifiedEvent  `json:"last_code_event,omitempty"`
-	SubmittedBefore  *bool          `json:"submitted_before,omitempty"`
-	TimeDifference   string         `json:"time_difference,omitempty"`
-	EventDescription string         `json:"event_description,omitempty"`
-	Error            string         `json:"error,omitempty"`
+	Found              bool          `json:"found"`
+	EventsChecked      int           `json:"events_checked"`
+	LastCodeEvent      *UnifiedEvent `json:"last_code_event,omitempty"`
+	LastBeforeDeadline *UnifiedEvent `json:"last_before_deadline,omitempty"`
+	LatePushes         int           `json:"late_pushes,omitempty"`
+	SubmittedBefore    *bool         `json:"submitted_before,omitempty"`
+	TimeDifference     string        `json:"time_difference,omitempty"`
+	EventDescription   string        `json:"event_description,omitempty"`
+	Error              string        `json:"error,omitempty"`
+	// TimeDifferenceSeconds 最后一次代码事件晚于截止时间的秒数（早于时为负数），TimeDifference 为按语言格式化的文字
+	TimeDifferenceSeconds *int64 `json:"time_difference_seconds,omitempty"`
+	// ErrorCode 与语言无关的错误类型（见 ErrorNoCodeEvents 等），Error 为按语言格式化的文字
+	ErrorCode string `json:"error_code,omitempty"`
+	// Deadline 分析时使用的截止时间（RFC3339），没有截止时间时为空
+	Deadline string `json:"deadline,omitempty"`
+	// EarliestCodeEvent 最早的代码事件（只在平台返回的事件范围内）
+	EarliestCodeEvent *UnifiedEvent `json:"earliest_code_event,omitempty"`
+	// PushesBeforeStart、CommitsBeforeStart 比赛开始前的推送次数和提交数（需要 AnalysisRequest.Start）
+	PushesBeforeStart  int `json:"pushes_before_start,omitempty"`
+	CommitsBeforeStart int `json:"commits_before_start,omitempty"`
+	// Confidence 判断是否准时的依据的可信度（见 ConfidenceHigh、ConfidenceLow）
+	Confidence string `json:"confidence,omitempty"`
+	// BranchCommits 没有推送事件时按提交时间判断的依据
+	BranchCommits []*BranchCommits `json:"branch_commits,omitempty"`
+	// Repository 仓库信息，Flags 为需要人工复核的标记（不是所有调用方都会填写）
+	Repository *Repository `json:"repository,omitempty"`
+	Flags      []string    `json:"flags,omitempty"`
+	// Lateness 迟交档位和扣分（见 monitor.ApplyLateness），没有设置宽限时间和扣分规则时为空
+	Lateness *Lateness `json:"lateness,omitempty"`
+	// Verdict 综合各类依据得出的结论（见 monitor.Evaluate）
+	Verdict *Verdict `json:"verdict,omitempty"`
 }
 
+// 分析结果的错误类型
+const (
+	ErrorNoCodeEvents     = "no_code_events"
+	ErrorInvalidStart     = "invalid_start"
+	ErrorInvalidDeadline  = "invalid_deadline"
+	ErrorInvalidEventTime = "invalid_event_time"
+	// ErrorNoPushEvents 没有推送事件，ErrorInvalidTime 推送时间无法解析（批量处理的错误分类）
+	ErrorNoPushEvents = "no_push_events"
+	ErrorInvalidTime  = "invalid_time"
+)
+
+// 判断依据的可信度
+const (
+	// ConfidenceHigh 依据平台记录的推送事件时间
+	ConfidenceHigh = "high"
+	// ConfidenceMedium 依据仓库的最后推送时间，无法区分分支
+	ConfidenceMedium = "medium"
+	// ConfidenceLow 依据提交时间，提交时间由提交者设置，可以被伪造
+	ConfidenceLow = "low"
+)
+
 // Platform 平台类型
 type Platform string
 
@@ -21,8 +66,12 @@ const (
 
 // AnalysisRequest 分析请求
 type AnalysisRequest struct {
-	Repository string    `json:"repository"`
-	Platform   Platform  `json:"platform"`
-	Token      string    `json:"token,omitempty"`
-	Deadline   string    `json:"deadline,omitempty"` // ISO 8601 格式
-}
\ No newline at end of file
+	Repository string   `json:"repository"`
+	Platform   Platform `json:"platform"`
+	Token      string   `json:"token,omitempty"`
+	Deadline   string   `json:"deadline,omitempty"` // ISO 8601 格式
+	// Start 比赛开始时间（ISO 8601 格式），为空时不统计开始前的推送
+	Start string `json:"start,omitempty"`
+	// Branches 只统计推送到这些分支的事件（支持通配符），为空时不限分支
+	Branches []string `json:"branches,omitempty"`
+}
@@ -0,0 +1,42 @@
+package models
+
+// 结论
+const (
+	VerdictOnTime       = "on_time"
+	VerdictLate         = "late"
+	VerdictUnknown      = "unknown"
+	VerdictEmpty        = "empty"
+	VerdictInaccessible = "inaccessible"
+)
+
+// 依据来源
+const (
+	// EvidencePushEvent 平台记录的推送事件（可信度高）
+	EvidencePushEvent = "push_event"
+	// EvidenceWebhook 本服务收到的 Webhook 推送（可信度高）
+	EvidenceWebhook = "webhook"
+	// EvidencePushedAt 仓库信息中的最后推送时间（可信度中）
+	EvidencePushedAt = "pushed_at"
+	// EvidenceCommit 提交者时间（可信度低）
+	EvidenceCommit = "commit"
+)
+
+// Verdict 结构化的结论：是否准时、可信度以及得出结论的依据
+type Verdict struct {
+	// Status 结论（on_time、late、unknown、empty、inaccessible）
+	Status string `json:"status"`
+	// Confidence 可信度（见 ConfidenceHigh 等），无法判断时为空
+	Confidence string     `json:"confidence,omitempty"`
+	Evidence   []Evidence `json:"evidence,omitempty"`
+}
+
+// Evidence 单条依据
+type Evidence struct {
+	// Source 来源（见 EvidencePushEvent 等）
+	Source string `json:"source"`
+	// Timestamp 依据中的时间（RFC3339）
+	Timestamp string `json:"timestamp"`
+	// URL 可以查看该依据的网页或 API 地址
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,56 @@
+// Package monitor 提供与平台无关的事件分析逻辑
+package monitor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// DeadlineStats 统计截止时间前后的代码事件
+// events 按时间倒序排列；返回截止时间前（含）最近的一个事件，以及截止时间之后的事件数量
+// 时间无法解析的事件不参与统计
+func DeadlineStats(events []*models.UnifiedEvent, deadline time.Time) (lastBefore *models.UnifiedEvent, late int) {
+	for _, event := range events {
+		eventTime, err := time.Parse(time.RFC3339, event.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if eventTime.After(deadline) {
+			late++
+			continue
+		}
+		if lastBefore == nil {
+			lastBefore = event
+		}
+	}
+	return lastBefore, late
+}
+
+// PushRef 从推送事件的 payload 中取出分支名和推送后的 HEAD 提交 SHA
+// GitHub 使用 head 字段，Gitee 使用 after 字段；字段不存在时返回空字符串
+func PushRef(event *models.UnifiedEvent) (branch, sha string) {
+	if event == nil || event.Payload == nil {
+		return "", ""
+	}
+
+	if ref, ok := event.Payload["ref"].(string); ok {
+		branch = strings.TrimPrefix(ref, "refs/heads/")
+	}
+	for _, key := range []string{"head", "after"} {
+		if value, ok := event.Payload[key].(string); ok && value != "" {
+			sha = value
+			break
+		}
+	}
+	return branch, sha
+}
+
+// SetTimeDifference 记录最后一次代码事件与截止时间的差（秒数和按当前语言格式化的文字）
+func SetTimeDifference(result *models.AnalysisResult, eventTime, deadline time.Time) {
+	seconds := int64(eventTime.Sub(deadline) / time.Second)
+	result.TimeDifferenceSeconds = &seconds
+	result.TimeDifference = i18n.TimeDifference(seconds)
+}
@@ -0,0 +1,58 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func event(id, createdAt string, payload map[string]interface{}) *models.UnifiedEvent {
+	return &models.UnifiedEvent{
+		BaseEvent: models.BaseEvent{ID: id, Type: "PushEvent", CreatedAt: createdAt},
+		Payload:   payload,
+	}
+}
+
+func TestDeadlineStats(t *testing.T) {
+	deadline := time.Date(2025, 9, 30, 16, 0, 0, 0, time.UTC)
+	events := []*models.UnifiedEvent{
+		event("4", "2025-10-02T08:00:00Z", nil),
+		event("3", "2025-10-01T08:00:00Z", nil),
+		event("2", "2025-09-30T16:00:00Z", nil),
+		event("1", "2025-09-29T08:00:00Z", nil),
+		event("0", "invalid", nil),
+	}
+
+	lastBefore, late := DeadlineStats(events, deadline)
+	if lastBefore == nil || lastBefore.ID != "2" {
+		t.Errorf("Expected event 2 to be the last one before deadline, got %+v", lastBefore)
+	}
+	if late != 2 {
+		t.Errorf("Expected 2 late events, got %d", late)
+	}
+
+	if lastBefore, late := DeadlineStats(events[:2], deadline); lastBefore != nil || late != 2 {
+		t.Errorf("Expected no event before deadline, got %+v and %d", lastBefore, late)
+	}
+}
+
+func TestPushRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload map[string]interface{}
+		branch  string
+		sha     string
+	}{
+		{"github", map[string]interface{}{"ref": "refs/heads/main", "head": "abc123"}, "main", "abc123"},
+		{"gitee", map[string]interface{}{"ref": "refs/heads/dev", "after": "def456"}, "dev", "def456"},
+		{"empty", nil, "", ""},
+	}
+
+	for _, tt := range tests {
+		branch, sha := PushRef(event("1", "2025-09-30T16:00:00Z", tt.payload))
+		if branch != tt.branch || sha != tt.sha {
+			t.Errorf("%s: got %q %q, want %q %q", tt.name, branch, sha, tt.branch, tt.sha)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package monitor
+
+import (
+	"path"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// BranchAllowed 判断分支是否计入提交，patterns 支持 path.Match 通配符（如 release/*）
+// patterns 为空时所有分支都计入；分支未知（空字符串）时也计入
+func BranchAllowed(branch string, patterns []string) bool {
+	if len(patterns) == 0 || branch == "" {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, branch); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterBranchEvents 只保留推送到允许分支的事件，patterns 为空时原样返回
+func FilterBranchEvents(events []*models.UnifiedEvent, patterns []string) []*models.UnifiedEvent {
+	if len(patterns) == 0 {
+		return events
+	}
+	var filtered []*models.UnifiedEvent
+	for _, event := range events {
+		if branch, _ := PushRef(event); BranchAllowed(branch, patterns) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// CommitBranches 没有推送事件时按提交时间检查的分支
+// 指定了分支规则时为这些分支，否则为仓库的默认分支；都不知道时返回 nil（检查所有分支，数量有上限）
+func CommitBranches(patterns []string, info *models.Repository) []string {
+	if len(patterns) > 0 {
+		return patterns
+	}
+	if info != nil && info.DefaultBranch != "" {
+		return []string{info.DefaultBranch}
+	}
+	return nil
+}
+
+// SelectBranches 从分支列表中选出要检查的分支：只保留允许的分支，最多 limit 个
+// patterns 中不带通配符的分支名（如默认分支）排在前面，其余按原顺序
+func SelectBranches(names []string, patterns []string, limit int) []string {
+	var exact, others []string
+	for _, name := range names {
+		switch {
+		case !BranchAllowed(name, patterns):
+		case containsString(patterns, name):
+			exact = append(exact, name)
+		default:
+			others = append(others, name)
+		}
+	}
+	selected := append(exact, others...)
+	if len(selected) > limit {
+		selected = selected[:limit]
+	}
+	return selected
+}
+
+// containsString 判断 values 中是否包含 s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
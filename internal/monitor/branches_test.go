@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestBranchAllowed(t *testing.T) {
+	patterns := []string{"main", "release/*"}
+	tests := []struct {
+		branch string
+		want   bool
+	}{
+		{"main", true},
+		{"release/v1", true},
+		{"release/v1/hotfix", false},
+		{"dev", false},
+		{"", true},
+	}
+	for _, tt := range tests {
+		if got := BranchAllowed(tt.branch, patterns); got != tt.want {
+			t.Errorf("BranchAllowed(%q) = %v, want %v", tt.branch, got, tt.want)
+		}
+	}
+	if !BranchAllowed("dev", nil) {
+		t.Error("Expected every branch to be allowed without patterns")
+	}
+}
+
+func TestFilterBranchEvents(t *testing.T) {
+	push := func(ref string) *models.UnifiedEvent {
+		return &models.UnifiedEvent{Payload: map[string]interface{}{"ref": ref}}
+	}
+	events := []*models.UnifiedEvent{push("refs/heads/dev"), push("refs/heads/main"), push("refs/heads/feature")}
+
+	filtered := FilterBranchEvents(events, []string{"main"})
+	if len(filtered) != 1 || filtered[0] != events[1] {
+		t.Errorf("Expected only the main push, got %d events", len(filtered))
+	}
+	if len(FilterBranchEvents(events, nil)) != 3 {
+		t.Error("Expected events to be kept without patterns")
+	}
+}
+
+func TestSelectBranches(t *testing.T) {
+	names := []string{"dev", "feature", "main", "release/v1"}
+	if got := strings.Join(SelectBranches(names, []string{"release/*", "main"}, 10), ","); got != "main,release/v1" {
+		t.Errorf("Expected exact names first, got %s", got)
+	}
+	if got := strings.Join(SelectBranches(names, nil, 2), ","); got != "dev,feature" {
+		t.Errorf("Expected the first 2 branches, got %s", got)
+	}
+}
+
+func TestCommitBranches(t *testing.T) {
+	info := &models.Repository{DefaultBranch: "main"}
+	if got := CommitBranches([]string{"release/*"}, info); len(got) != 1 || got[0] != "release/*" {
+		t.Errorf("Expected the track branches, got %v", got)
+	}
+	if got := CommitBranches(nil, info); len(got) != 1 || got[0] != "main" {
+		t.Errorf("Expected the default branch, got %v", got)
+	}
+	if got := CommitBranches(nil, nil); got != nil {
+		t.Errorf("Expected every branch without repository info, got %v", got)
+	}
+}
@@ -0,0 +1,53 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// ApplyBranchCommits 没有推送事件时，按各分支截止时间前后最新提交的提交时间判断是否准时
+// 任一分支在截止时间后有提交即为超时，否则有截止时间前的提交即为准时；结果标记为低可信度。
+// 所有分支都没有提交时不修改 result 并返回 false
+func ApplyBranchCommits(result *models.AnalysisResult, branches []*models.BranchCommits, deadline time.Time) bool {
+	var newest *models.Commit
+	var newestBranch string
+	var newestTime time.Time
+	late := false
+
+	for _, branch := range branches {
+		for _, commit := range []*models.Commit{branch.After, branch.Before} {
+			if commit == nil {
+				continue
+			}
+			committedAt, err := time.Parse(time.RFC3339, commit.CommittedAt)
+			if err != nil {
+				continue
+			}
+			// 截止时间后的提交优先于截止时间前的提交
+			isLate := committedAt.After(deadline)
+			if newest == nil || (isLate && !late) || (isLate == late && committedAt.After(newestTime)) {
+				newest, newestBranch, newestTime, late = commit, branch.Branch, committedAt, isLate
+			}
+		}
+	}
+	if newest == nil {
+		return false
+	}
+
+	onTime := !late
+	result.SubmittedBefore = &onTime
+	result.Confidence = models.ConfidenceLow
+	result.BranchCommits = branches
+	result.EventDescription = i18n.T(i18n.MsgNewestCommit, shortSHA(newest.SHA), newestBranch, newest.CommittedAt)
+	return true
+}
+
+// shortSHA 返回提交 SHA 的前 7 位
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
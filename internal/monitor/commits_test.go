@@ -0,0 +1,62 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestApplyBranchCommits(t *testing.T) {
+	deadline := time.Date(2025, 9, 30, 16, 0, 0, 0, time.UTC)
+	commit := func(sha, committedAt string) *models.Commit {
+		return &models.Commit{SHA: sha, CommittedAt: committedAt}
+	}
+
+	tests := []struct {
+		name     string
+		branches []*models.BranchCommits
+		want     *bool
+		desc     string
+	}{
+		{"no branches", nil, nil, ""},
+		{"no commits", []*models.BranchCommits{{Branch: "main"}}, nil, ""},
+		{"on time", []*models.BranchCommits{
+			{Branch: "main", Before: commit("aaaaaaaaaa", "2025-09-29T10:00:00Z")},
+			{Branch: "dev", Before: commit("bbbbbbbbbb", "2025-09-30T16:00:00Z")},
+		}, boolPtr(true), "Newest commit bbbbbbb on dev (2025-09-30T16:00:00Z)"},
+		{"late on one branch", []*models.BranchCommits{
+			{Branch: "main", Before: commit("aaaaaaaaaa", "2025-09-30T15:00:00Z")},
+			{Branch: "fix", Before: commit("bbbbbbbbbb", "2025-09-29T10:00:00Z"), After: commit("cccccccccc", "2025-10-01T08:00:00+08:00")},
+		}, boolPtr(false), "Newest commit ccccccc on fix (2025-10-01T08:00:00+08:00)"},
+		{"unparseable dates ignored", []*models.BranchCommits{
+			{Branch: "main", Before: commit("aaaaaaaaaa", "2025-09-29T10:00:00Z"), After: commit("bad", "unknown")},
+		}, boolPtr(true), "Newest commit aaaaaaa on main (2025-09-29T10:00:00Z)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &models.AnalysisResult{}
+			applied := ApplyBranchCommits(result, tt.branches, deadline)
+			if applied != (tt.want != nil) {
+				t.Fatalf("ApplyBranchCommits() = %v", applied)
+			}
+			if tt.want == nil {
+				if result.SubmittedBefore != nil || result.Confidence != "" {
+					t.Errorf("Expected result to be unchanged, got %+v", result)
+				}
+				return
+			}
+			if *result.SubmittedBefore != *tt.want || result.Confidence != models.ConfidenceLow {
+				t.Errorf("Expected submitted before %v with low confidence, got %+v", *tt.want, result)
+			}
+			if result.EventDescription != tt.desc {
+				t.Errorf("EventDescription = %q, want %q", result.EventDescription, tt.desc)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
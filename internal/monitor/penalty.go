@@ -0,0 +1,96 @@
+package monitor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// ApplyLateness 按宽限时间和扣分档位计算最后一次提交的迟交档位和扣分
+// 最后一次提交取最近的推送事件，没有推送事件时取各分支最新的提交；
+// 宽限时间内的提交视为准时（SubmittedBefore 改为 true）。没有判断结果时不修改 result
+func ApplyLateness(result *models.AnalysisResult, deadline time.Time, policy models.LatenessPolicy) {
+	if result == nil || result.SubmittedBefore == nil {
+		return
+	}
+	submitted, ok := submissionTime(result)
+	if !ok {
+		return
+	}
+
+	lateness := submitted.Sub(deadline)
+	result.Lateness = &models.Lateness{Seconds: int64(lateness / time.Second)}
+	switch {
+	case lateness <= 0:
+		result.Lateness.Tier = models.TierOnTime
+	case lateness <= policy.Grace:
+		result.Lateness.Tier = models.TierGrace
+		onTime := true
+		result.SubmittedBefore = &onTime
+	default:
+		result.Lateness.Tier = models.TierLate
+		for _, tier := range policy.Tiers {
+			if lateness < tier.After {
+				break
+			}
+			hours := math.Ceil((lateness - tier.After).Hours())
+			result.Lateness.Tier = tier.Name
+			result.Lateness.Penalty = tier.Points + tier.PerHour*hours
+		}
+	}
+}
+
+// submissionTime 最后一次提交的时间
+func submissionTime(result *models.AnalysisResult) (time.Time, bool) {
+	if result.LastCodeEvent != nil {
+		t, err := time.Parse(time.RFC3339, result.LastCodeEvent.CreatedAt)
+		return t, err == nil
+	}
+
+	var newest time.Time
+	for _, branch := range result.BranchCommits {
+		for _, commit := range []*models.Commit{branch.Before, branch.After} {
+			if commit == nil {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339, commit.CommittedAt); err == nil && t.After(newest) {
+				newest = t
+			}
+		}
+	}
+	return newest, !newest.IsZero()
+}
+
+// ParsePenaltyTier 解析 "AFTER:POINTS[:PER_HOUR]" 形式的扣分档位，如 "15m:0:5"、"24h:100"
+// 档位名称为 "+AFTER"
+func ParsePenaltyTier(value string) (models.PenaltyTier, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return models.PenaltyTier{}, fmt.Errorf("invalid penalty tier %q, expected AFTER:POINTS[:PER_HOUR]", value)
+	}
+
+	after, err := time.ParseDuration(parts[0])
+	if err != nil || after < 0 {
+		return models.PenaltyTier{}, fmt.Errorf("invalid penalty tier %q: bad duration %q", value, parts[0])
+	}
+	tier := models.PenaltyTier{Name: "+" + parts[0], After: after}
+	if tier.Points, err = strconv.ParseFloat(parts[1], 64); err != nil {
+		return models.PenaltyTier{}, fmt.Errorf("invalid penalty tier %q: bad points %q", value, parts[1])
+	}
+	if len(parts) == 3 {
+		if tier.PerHour, err = strconv.ParseFloat(parts[2], 64); err != nil {
+			return models.PenaltyTier{}, fmt.Errorf("invalid penalty tier %q: bad points per hour %q", value, parts[2])
+		}
+	}
+	return tier, nil
+}
+
+// SortPenaltyTiers 按 After 从小到大排列扣分档位
+func SortPenaltyTiers(tiers []models.PenaltyTier) {
+	sort.SliceStable(tiers, func(i, j int) bool { return tiers[i].After < tiers[j].After })
+}
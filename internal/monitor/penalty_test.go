@@ -0,0 +1,83 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestApplyLateness(t *testing.T) {
+	deadline := time.Date(2025, 9, 30, 16, 0, 0, 0, time.UTC)
+	policy := models.LatenessPolicy{
+		Grace: 15 * time.Minute,
+		Tiers: []models.PenaltyTier{
+			{Name: "+15m", After: 15 * time.Minute, PerHour: 5},
+			{Name: "+24h", After: 24 * time.Hour, Points: 100},
+		},
+	}
+	pushedAt := func(createdAt string) *models.AnalysisResult {
+		return &models.AnalysisResult{
+			LastCodeEvent:   &models.UnifiedEvent{BaseEvent: models.BaseEvent{CreatedAt: createdAt}},
+			SubmittedBefore: boolPtr(false),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		result  *models.AnalysisResult
+		onTime  bool
+		tier    string
+		penalty float64
+	}{
+		{"on time", &models.AnalysisResult{
+			LastCodeEvent:   &models.UnifiedEvent{BaseEvent: models.BaseEvent{CreatedAt: "2025-09-30T15:00:00Z"}},
+			SubmittedBefore: boolPtr(true),
+		}, true, models.TierOnTime, 0},
+		{"within grace", pushedAt("2025-09-30T16:15:00Z"), true, models.TierGrace, 0},
+		{"first tier, partial hour", pushedAt("2025-09-30T16:20:00Z"), false, "+15m", 5},
+		{"first tier, three hours", pushedAt("2025-09-30T19:15:00Z"), false, "+15m", 15},
+		{"second tier", pushedAt("2025-10-01T17:00:00Z"), false, "+24h", 100},
+		{"commit dates", &models.AnalysisResult{
+			SubmittedBefore: boolPtr(false),
+			BranchCommits: []*models.BranchCommits{
+				{Branch: "main", Before: &models.Commit{CommittedAt: "2025-09-30T10:00:00Z"}, After: &models.Commit{CommittedAt: "2025-09-30T16:10:00Z"}},
+			},
+		}, true, models.TierGrace, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ApplyLateness(tt.result, deadline, policy)
+			lateness := tt.result.Lateness
+			if lateness == nil {
+				t.Fatal("Expected lateness to be set")
+			}
+			if *tt.result.SubmittedBefore != tt.onTime || lateness.Tier != tt.tier || lateness.Penalty != tt.penalty {
+				t.Errorf("Expected %v/%s/%v, got %v/%s/%v", tt.onTime, tt.tier, tt.penalty, *tt.result.SubmittedBefore, lateness.Tier, lateness.Penalty)
+			}
+		})
+	}
+
+	undetermined := &models.AnalysisResult{}
+	ApplyLateness(undetermined, deadline, policy)
+	if undetermined.Lateness != nil {
+		t.Error("Expected no lateness without a verdict")
+	}
+}
+
+func TestParsePenaltyTier(t *testing.T) {
+	tier, err := ParsePenaltyTier("15m:0:5")
+	if err != nil {
+		t.Fatalf("ParsePenaltyTier failed: %v", err)
+	}
+	if tier.Name != "+15m" || tier.After != 15*time.Minute || tier.Points != 0 || tier.PerHour != 5 {
+		t.Errorf("Unexpected tier: %+v", tier)
+	}
+
+	for _, value := range []string{"15m", "soon:1", "1h:x", "1h:1:x", "1h:1:2:3"} {
+		if _, err := ParsePenaltyTier(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// 仓库标记
+const (
+	// FlagCreatedBeforeStart 仓库在比赛开始前创建
+	FlagCreatedBeforeStart = "created_before_start"
+	// FlagPrivate 仓库不是公开仓库
+	FlagPrivate = "private"
+	// FlagFork 仓库 fork 自已有项目
+	FlagFork = "fork"
+	// FlagArchived 仓库已归档
+	FlagArchived = "archived"
+)
+
+// RepositoryFlags 根据仓库信息给出需要人工复核的标记
+// start 为比赛开始时间，为零值时不检查创建时间；创建时间无法解析时同样不检查
+func RepositoryFlags(repo *models.Repository, start time.Time) []string {
+	if repo == nil {
+		return nil
+	}
+
+	var flags []string
+	if !start.IsZero() {
+		if created, err := time.Parse(time.RFC3339, repo.CreatedAt); err == nil && created.Before(start) {
+			flags = append(flags, FlagCreatedBeforeStart)
+		}
+	}
+	if repo.Visibility != "" && repo.Visibility != models.VisibilityPublic {
+		flags = append(flags, FlagPrivate)
+	}
+	if repo.Fork {
+		flags = append(flags, FlagFork)
+	}
+	if repo.Archived {
+		flags = append(flags, FlagArchived)
+	}
+	return flags
+}
@@ -0,0 +1,38 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestRepositoryFlags(t *testing.T) {
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		repo  *models.Repository
+		start time.Time
+		want  string
+	}{
+		{"nil", nil, start, ""},
+		{"clean", &models.Repository{Visibility: "public", CreatedAt: "2025-09-02T00:00:00Z"}, start, ""},
+		{"created before start", &models.Repository{Visibility: "public", CreatedAt: "2025-08-31T23:59:59Z"}, start, FlagCreatedBeforeStart},
+		{"no start", &models.Repository{Visibility: "public", CreatedAt: "2020-01-01T00:00:00Z"}, time.Time{}, ""},
+		{"unparseable created_at", &models.Repository{Visibility: "public", CreatedAt: "yesterday"}, start, ""},
+		{"private fork archived", &models.Repository{Visibility: "private", Fork: true, Archived: true}, start,
+			FlagPrivate + "," + FlagFork + "," + FlagArchived},
+		{"internal", &models.Repository{Visibility: "internal"}, start, FlagPrivate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(RepositoryFlags(tt.repo, tt.start), ",")
+			if got != tt.want {
+				t.Errorf("RepositoryFlags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// FlagPushedBeforeStart 比赛开始前推送了较多提交
+const FlagPushedBeforeStart = "pushed_before_start"
+
+// DefaultCommitsBeforeStart 未设置阈值时，开始前推送的提交数达到该值时标记 FlagPushedBeforeStart，
+// 开始前只推送一两个提交（如初始化项目）不标记
+const DefaultCommitsBeforeStart = 3
+
+// StartStats 统计比赛开始前的代码事件
+// events 按时间倒序排列；返回开始前（不含）的推送次数和提交数，时间无法解析的事件不参与统计
+func StartStats(events []*models.UnifiedEvent, start time.Time) (pushes, commits int) {
+	for _, event := range events {
+		eventTime, err := time.Parse(time.RFC3339, event.CreatedAt)
+		if err != nil || !eventTime.Before(start) {
+			continue
+		}
+		pushes++
+		commits += PushCommitCount(event)
+	}
+	return pushes, commits
+}
+
+// PushCommitCount 推送事件中的提交数
+// 优先使用 payload 的 size 字段，其次为 commits 列表的长度，都没有时按 1 个提交计算
+func PushCommitCount(event *models.UnifiedEvent) int {
+	if event == nil || event.Payload == nil {
+		return 1
+	}
+	if size, ok := event.Payload["size"].(float64); ok {
+		return int(size)
+	}
+	if size, ok := event.Payload["size"].(int); ok {
+		return size
+	}
+	if commits, ok := event.Payload["commits"].([]interface{}); ok {
+		return len(commits)
+	}
+	return 1
+}
+
+// ActivityFlags 根据分析结果中的推送记录给出需要人工复核的标记
+// threshold 为标记 FlagPushedBeforeStart 的开始前提交数，为 0 时使用 DefaultCommitsBeforeStart
+func ActivityFlags(result *models.AnalysisResult, threshold int) []string {
+	if result == nil {
+		return nil
+	}
+	if threshold <= 0 {
+		threshold = DefaultCommitsBeforeStart
+	}
+	var flags []string
+	if result.CommitsBeforeStart >= threshold {
+		flags = append(flags, FlagPushedBeforeStart)
+	}
+	return flags
+}
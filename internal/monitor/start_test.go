@@ -0,0 +1,44 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestStartStats(t *testing.T) {
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	push := func(createdAt string, payload map[string]interface{}) *models.UnifiedEvent {
+		return &models.UnifiedEvent{BaseEvent: models.BaseEvent{CreatedAt: createdAt}, Payload: payload}
+	}
+	events := []*models.UnifiedEvent{
+		push("2025-09-02T10:00:00Z", map[string]interface{}{"size": float64(7)}),
+		push("2025-09-01T00:00:00Z", map[string]interface{}{"size": float64(5)}),
+		push("2025-08-31T12:00:00Z", map[string]interface{}{"size": float64(2)}),
+		push("2025-08-30T12:00:00Z", map[string]interface{}{"commits": []interface{}{"a", "b", "c"}}),
+		push("2025-08-29T12:00:00Z", nil),
+		push("yesterday", map[string]interface{}{"size": float64(9)}),
+	}
+
+	pushes, commits := StartStats(events, start)
+	if pushes != 3 || commits != 6 {
+		t.Errorf("Expected 3 pushes and 6 commits before start, got %d and %d", pushes, commits)
+	}
+}
+
+func TestActivityFlags(t *testing.T) {
+	if flags := ActivityFlags(&models.AnalysisResult{PushesBeforeStart: 1, CommitsBeforeStart: 2}, 0); len(flags) != 0 {
+		t.Errorf("Expected no flags for a small initial push, got %v", flags)
+	}
+	flags := ActivityFlags(&models.AnalysisResult{PushesBeforeStart: 2, CommitsBeforeStart: DefaultCommitsBeforeStart}, 0)
+	if len(flags) != 1 || flags[0] != FlagPushedBeforeStart {
+		t.Errorf("Expected %s, got %v", FlagPushedBeforeStart, flags)
+	}
+	if flags := ActivityFlags(&models.AnalysisResult{PushesBeforeStart: 2, CommitsBeforeStart: DefaultCommitsBeforeStart}, 10); len(flags) != 0 {
+		t.Errorf("Expected no flags below a custom threshold, got %v", flags)
+	}
+	if ActivityFlags(nil, 0) != nil {
+		t.Error("Expected no flags for nil result")
+	}
+}
@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DisplayLayout 显示时间的格式
+const DisplayLayout = "2006-01-02 15:04:05"
+
+// timeLayouts 除 RFC3339 外接受的时间写法，不带时区的写法按指定时区解析
+var timeLayouts = []string{
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+}
+
+// ParseTime 解析 RFC3339 时间或常见的不带时区写法（如 "2025-09-30 23:59"）
+// 不带时区的写法按 loc 解析，loc 为 nil 时按本地时区
+func ParseTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	for _, layout := range timeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse time %q (expected RFC3339 or 2006-01-02 15:04[:05])", value)
+}
+
+// NormalizeTime 把 ParseTime 接受的时间转换为 RFC3339（保留时区偏移）
+func NormalizeTime(value string, loc *time.Location) (string, error) {
+	parsed, err := ParseTime(value, loc)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Format(time.RFC3339), nil
+}
+
+// DualTime 同时按 loc 和 UTC 显示时间，如 "2025-09-30 23:59:00 CST (2025-09-30 15:59:00 UTC)"
+// loc 在该时刻与 UTC 没有偏移时只显示 UTC
+func DualTime(t time.Time, loc *time.Location) string {
+	utc := t.UTC().Format(DisplayLayout) + " UTC"
+	if loc == nil {
+		return utc
+	}
+	local := t.In(loc)
+	if _, offset := local.Zone(); offset == 0 {
+		return utc
+	}
+	return local.Format(DisplayLayout+" MST") + " (" + utc + ")"
+}
+
+// FormatDualTime 把 RFC3339 时间按 DualTime 显示，为空或无法解析时原样返回
+func FormatDualTime(value string, loc *time.Location) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return DualTime(t, loc)
+}
@@ -0,0 +1,58 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	want := time.Date(2025, 9, 30, 15, 59, 0, 0, time.UTC)
+
+	for _, value := range []string{
+		"2025-09-30T23:59:00+08:00",
+		"2025-09-30T15:59:00Z",
+		"2025-09-30 23:59:00+08:00",
+		"2025-09-30 23:59",
+		"2025-09-30 23:59:00",
+		"2025-09-30T23:59",
+		"2025/09/30 23:59",
+		" 2025/09/30 23:59:00 ",
+	} {
+		got, err := ParseTime(value, shanghai)
+		if err != nil {
+			t.Errorf("ParseTime(%q) failed: %v", value, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseTime(%q) = %v, want %v", value, got, want)
+		}
+	}
+
+	for _, value := range []string{"", "tomorrow", "30/09/2025 23:59"} {
+		if _, err := ParseTime(value, shanghai); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
+func TestNormalizeTime(t *testing.T) {
+	got, err := NormalizeTime("2025-09-30 23:59", time.FixedZone("", 8*3600))
+	if err != nil || got != "2025-09-30T23:59:00+08:00" {
+		t.Errorf("Expected 2025-09-30T23:59:00+08:00, got %q (%v)", got, err)
+	}
+}
+
+func TestDualTime(t *testing.T) {
+	moment := time.Date(2025, 9, 30, 15, 59, 0, 0, time.UTC)
+
+	if got := DualTime(moment, time.FixedZone("CST", 8*3600)); got != "2025-09-30 23:59:00 CST (2025-09-30 15:59:00 UTC)" {
+		t.Errorf("Unexpected dual time: %s", got)
+	}
+	if got := DualTime(moment, time.UTC); got != "2025-09-30 15:59:00 UTC" {
+		t.Errorf("Expected UTC only, got %s", got)
+	}
+	if got := FormatDualTime("not a time", time.UTC); got != "not a time" {
+		t.Errorf("Expected unparsable value unchanged, got %s", got)
+	}
+}
@@ -0,0 +1,124 @@
+package monitor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// githubAPIRepos GitHub 事件中仓库地址的 API 前缀
+const githubAPIRepos = "https://api.github.com/repos/"
+
+// Evaluate 综合分析结果和仓库信息中的各类依据得出结论
+// 有推送事件或提交时间的判断时沿用其结论和可信度；否则按仓库的最后推送时间判断（可信度中）。
+// 推送事件判断为准时、但最后推送时间晚于截止时间时，说明事件可能不完整，可信度降为中。
+// grace 为截止后的宽限时间，宽限内的最后推送时间视为准时；
+// repo 可以为 nil；deadline 为零值时只收集依据，结论为 unknown
+func Evaluate(result *models.AnalysisResult, repo *models.Repository, deadline time.Time, grace time.Duration) *models.Verdict {
+	verdict := &models.Verdict{Status: models.VerdictUnknown}
+	if result != nil {
+		verdict.Evidence = append(eventEvidence(result, repo), commitEvidence(result)...)
+	}
+
+	var pushedAt time.Time
+	if repo != nil && repo.PushedAt != "" {
+		verdict.Evidence = append(verdict.Evidence, models.Evidence{
+			Source:      models.EvidencePushedAt,
+			Timestamp:   repo.PushedAt,
+			URL:         repo.HTMLURL,
+			Description: i18n.T(i18n.MsgEvidencePushedAt),
+		})
+		pushedAt, _ = time.Parse(time.RFC3339, repo.PushedAt)
+	}
+
+	switch {
+	case result != nil && result.SubmittedBefore != nil:
+		verdict.Status = models.VerdictLate
+		if *result.SubmittedBefore {
+			verdict.Status = models.VerdictOnTime
+		}
+		verdict.Confidence = result.Confidence
+		if verdict.Confidence == "" {
+			verdict.Confidence = models.ConfidenceHigh
+		}
+		if verdict.Status == models.VerdictOnTime && verdict.Confidence == models.ConfidenceHigh &&
+			!deadline.IsZero() && pushedAt.After(deadline.Add(grace)) {
+			verdict.Confidence = models.ConfidenceMedium
+		}
+	case !deadline.IsZero() && !pushedAt.IsZero():
+		verdict.Status = models.VerdictOnTime
+		if pushedAt.After(deadline.Add(grace)) {
+			verdict.Status = models.VerdictLate
+		}
+		verdict.Confidence = models.ConfidenceMedium
+	}
+	return verdict
+}
+
+// eventEvidence 最近的推送事件以及截止时间前最后的推送事件
+func eventEvidence(result *models.AnalysisResult, repo *models.Repository) []models.Evidence {
+	var evidence []models.Evidence
+	add := func(event *models.UnifiedEvent, label string) {
+		branch, sha := PushRef(event)
+		description := i18n.T(i18n.MsgEvidenceEvent, event.Type, event.ActorLogin, i18n.T(label))
+		if branch != "" {
+			description = i18n.T(i18n.MsgEvidenceEventBranch, event.Type, event.ActorLogin, branch, i18n.T(label))
+		}
+		evidence = append(evidence, models.Evidence{
+			Source:      models.EvidencePushEvent,
+			Timestamp:   event.CreatedAt,
+			URL:         commitURL(repoWebURL(event, repo), sha),
+			Description: description,
+		})
+	}
+
+	last, before := result.LastCodeEvent, result.LastBeforeDeadline
+	if last != nil {
+		add(last, i18n.MsgEvidenceLatest)
+	}
+	// 最近的事件在截止时间前时，两者是同一个事件
+	if before != nil && (last == nil || before.ID != last.ID || before.CreatedAt != last.CreatedAt) {
+		add(before, i18n.MsgEvidenceBefore)
+	}
+	return evidence
+}
+
+// commitEvidence 按提交时间判断时，各分支截止时间前后最新的提交
+func commitEvidence(result *models.AnalysisResult) []models.Evidence {
+	var evidence []models.Evidence
+	for _, branch := range result.BranchCommits {
+		for _, commit := range []*models.Commit{branch.Before, branch.After} {
+			if commit == nil {
+				continue
+			}
+			evidence = append(evidence, models.Evidence{
+				Source:      models.EvidenceCommit,
+				Timestamp:   commit.CommittedAt,
+				URL:         commit.URL,
+				Description: i18n.T(i18n.MsgEvidenceCommit, shortSHA(commit.SHA), branch.Branch),
+			})
+		}
+	}
+	return evidence
+}
+
+// repoWebURL 仓库网页地址，优先使用仓库信息；GitHub 事件中的地址为 API 地址，需要转换
+func repoWebURL(event *models.UnifiedEvent, repo *models.Repository) string {
+	if repo != nil && repo.HTMLURL != "" {
+		return repo.HTMLURL
+	}
+	if strings.HasPrefix(event.RepoURL, githubAPIRepos) {
+		return "https://github.com/" + strings.TrimPrefix(event.RepoURL, githubAPIRepos)
+	}
+	return event.RepoURL
+}
+
+// commitURL 提交的网页地址，没有 SHA 时返回仓库地址
+func commitURL(repoURL, sha string) string {
+	if repoURL == "" || sha == "" {
+		return repoURL
+	}
+	return strings.TrimSuffix(repoURL, "/") + "/commit/" + sha
+}
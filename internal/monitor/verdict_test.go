@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestEvaluate(t *testing.T) {
+	deadline := time.Date(2025, 9, 30, 16, 0, 0, 0, time.UTC)
+	push := &models.UnifiedEvent{
+		BaseEvent:  models.BaseEvent{ID: "1", Type: "PushEvent", CreatedAt: "2025-09-30T10:00:00Z"},
+		ActorLogin: "alice",
+		RepoURL:    "https://api.github.com/repos/team/repo",
+		Payload:    map[string]interface{}{"ref": "refs/heads/main", "head": "abc123"},
+	}
+	repo := func(pushedAt string) *models.Repository {
+		return &models.Repository{HTMLURL: "https://github.com/team/repo", PushedAt: pushedAt}
+	}
+
+	tests := []struct {
+		name       string
+		result     *models.AnalysisResult
+		repo       *models.Repository
+		status     string
+		confidence string
+		sources    []string
+	}{
+		{"push event", &models.AnalysisResult{LastCodeEvent: push, SubmittedBefore: boolPtr(true), Confidence: models.ConfidenceHigh},
+			nil, models.VerdictOnTime, models.ConfidenceHigh, []string{models.EvidencePushEvent}},
+		{"push event contradicted by pushed_at", &models.AnalysisResult{LastCodeEvent: push, SubmittedBefore: boolPtr(true)},
+			repo("2025-10-01T00:00:00Z"), models.VerdictOnTime, models.ConfidenceMedium, []string{models.EvidencePushEvent, models.EvidencePushedAt}},
+		{"commit dates", &models.AnalysisResult{SubmittedBefore: boolPtr(false), Confidence: models.ConfidenceLow, BranchCommits: []*models.BranchCommits{
+			{Branch: "main", Before: &models.Commit{SHA: "a", CommittedAt: "2025-09-29T00:00:00Z"}, After: &models.Commit{SHA: "b", CommittedAt: "2025-10-01T00:00:00Z"}},
+		}}, nil, models.VerdictLate, models.ConfidenceLow, []string{models.EvidenceCommit, models.EvidenceCommit}},
+		{"pushed_at only", &models.AnalysisResult{}, repo("2025-09-30T12:00:00Z"),
+			models.VerdictOnTime, models.ConfidenceMedium, []string{models.EvidencePushedAt}},
+		{"nothing", &models.AnalysisResult{}, nil, models.VerdictUnknown, "", nil},
+		{"pushed_at within grace", &models.AnalysisResult{}, repo("2025-09-30T16:10:00Z"),
+			models.VerdictOnTime, models.ConfidenceMedium, []string{models.EvidencePushedAt}},
+		{"pushed_at after grace", &models.AnalysisResult{}, repo("2025-09-30T16:20:00Z"),
+			models.VerdictLate, models.ConfidenceMedium, []string{models.EvidencePushedAt}},
+		{"push event with pushed_at within grace", &models.AnalysisResult{LastCodeEvent: push, SubmittedBefore: boolPtr(true)},
+			repo("2025-09-30T16:10:00Z"), models.VerdictOnTime, models.ConfidenceHigh, []string{models.EvidencePushEvent, models.EvidencePushedAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := Evaluate(tt.result, tt.repo, deadline, 15*time.Minute)
+			if verdict.Status != tt.status || verdict.Confidence != tt.confidence {
+				t.Errorf("Expected %s/%s, got %s/%s", tt.status, tt.confidence, verdict.Status, verdict.Confidence)
+			}
+			if len(verdict.Evidence) != len(tt.sources) {
+				t.Fatalf("Expected %d evidence items, got %+v", len(tt.sources), verdict.Evidence)
+			}
+			for i, source := range tt.sources {
+				if verdict.Evidence[i].Source != source {
+					t.Errorf("Evidence %d: expected source %s, got %s", i, source, verdict.Evidence[i].Source)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluate_PushEventURL(t *testing.T) {
+	event := &models.UnifiedEvent{
+		BaseEvent: models.BaseEvent{ID: "1", Type: "PushEvent", CreatedAt: "2025-09-30T10:00:00Z"},
+		RepoURL:   "https://api.github.com/repos/team/repo",
+		Payload:   map[string]interface{}{"ref": "refs/heads/main", "head": "abc123"},
+	}
+
+	verdict := Evaluate(&models.AnalysisResult{LastCodeEvent: event}, nil, time.Time{}, 0)
+	if verdict.Status != models.VerdictUnknown {
+		t.Errorf("Expected unknown without deadline, got %s", verdict.Status)
+	}
+	if got := verdict.Evidence[0].URL; got != "https://github.com/team/repo/commit/abc123" {
+		t.Errorf("Unexpected URL: %s", got)
+	}
+}
@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// ApplyWebhookEvents 把本服务收到的 Webhook 推送加入结论的依据
+// events 为该仓库的 Webhook 事件（按接收时间倒序），只使用允许分支上的推送。
+// Webhook 的接收时间是独立于平台的来源：结论可信度不高时改按最近的 Webhook 推送判断（可信度高）；
+// 推送事件判断为准时、但最近的 Webhook 推送晚于截止时间时改为超时；grace 为截止后的宽限时间，宽限内的推送视为准时。
+// 不可访问和空仓库的结论保持不变；deadline 为零值时只收集依据
+func ApplyWebhookEvents(verdict *models.Verdict, events []*models.UnifiedEvent, deadline time.Time, grace time.Duration, branches []string) {
+	if verdict == nil || verdict.Status == models.VerdictInaccessible || verdict.Status == models.VerdictEmpty {
+		return
+	}
+	cutoff := deadline
+	if !deadline.IsZero() {
+		cutoff = deadline.Add(grace)
+	}
+
+	var latest, before *models.UnifiedEvent
+	var latestTime time.Time
+	for _, event := range events {
+		if event.Type != "PushEvent" {
+			continue
+		}
+		branch, _ := PushRef(event)
+		if !BranchAllowed(branch, branches) {
+			continue
+		}
+		receivedAt, err := time.Parse(time.RFC3339, event.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if latest == nil {
+			latest, latestTime = event, receivedAt
+		}
+		if !deadline.IsZero() && !receivedAt.After(cutoff) {
+			before = event
+			break
+		}
+	}
+	if latest == nil {
+		return
+	}
+
+	verdict.Evidence = append(verdict.Evidence, webhookEvidence(latest, i18n.MsgEvidenceLatest))
+	if before != nil && before != latest {
+		verdict.Evidence = append(verdict.Evidence, webhookEvidence(before, i18n.MsgEvidenceBefore))
+	}
+	if deadline.IsZero() {
+		return
+	}
+
+	late := latestTime.After(cutoff)
+	switch {
+	case verdict.Confidence != models.ConfidenceHigh:
+		verdict.Status = models.VerdictOnTime
+		if late {
+			verdict.Status = models.VerdictLate
+		}
+		verdict.Confidence = models.ConfidenceHigh
+	case verdict.Status == models.VerdictOnTime && late:
+		verdict.Status = models.VerdictLate
+	}
+}
+
+// webhookEvidence 一次 Webhook 推送的依据，时间为本服务的接收时间
+func webhookEvidence(event *models.UnifiedEvent, label string) models.Evidence {
+	branch, sha := PushRef(event)
+	return models.Evidence{
+		Source:      models.EvidenceWebhook,
+		Timestamp:   event.CreatedAt,
+		URL:         commitURL(repoWebURL(event, nil), sha),
+		Description: i18n.T(i18n.MsgEvidenceWebhook, event.ActorLogin, branch, i18n.T(label)),
+	}
+}
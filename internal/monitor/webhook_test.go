@@ -0,0 +1,92 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestApplyWebhookEvents(t *testing.T) {
+	deadline := time.Date(2025, 9, 30, 16, 0, 0, 0, time.UTC)
+	push := func(receivedAt, branch string) *models.UnifiedEvent {
+		return &models.UnifiedEvent{
+			BaseEvent:  models.BaseEvent{Type: "PushEvent", CreatedAt: receivedAt},
+			ActorLogin: "alice",
+			RepoURL:    "https://github.com/team/repo",
+			Payload:    map[string]interface{}{"ref": "refs/heads/" + branch, "after": "abc123"},
+		}
+	}
+	// 按接收时间倒序
+	events := []*models.UnifiedEvent{
+		push("2025-10-01T00:00:00Z", "dev"),
+		push("2025-09-30T17:00:00Z", "main"),
+		push("2025-09-30T12:00:00Z", "main"),
+	}
+
+	tests := []struct {
+		name       string
+		verdict    *models.Verdict
+		branches   []string
+		status     string
+		confidence string
+		evidence   int
+	}{
+		{"unknown becomes late", &models.Verdict{Status: models.VerdictUnknown}, nil, models.VerdictLate, models.ConfidenceHigh, 2},
+		{"low confidence on time becomes late", &models.Verdict{Status: models.VerdictOnTime, Confidence: models.ConfidenceLow}, []string{"main"}, models.VerdictLate, models.ConfidenceHigh, 2},
+		{"high confidence on time contradicted", &models.Verdict{Status: models.VerdictOnTime, Confidence: models.ConfidenceHigh}, nil, models.VerdictLate, models.ConfidenceHigh, 2},
+		{"high confidence late kept", &models.Verdict{Status: models.VerdictLate, Confidence: models.ConfidenceHigh}, []string{"release"}, models.VerdictLate, models.ConfidenceHigh, 0},
+		{"inaccessible kept", &models.Verdict{Status: models.VerdictInaccessible, Confidence: models.ConfidenceHigh}, nil, models.VerdictInaccessible, models.ConfidenceHigh, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ApplyWebhookEvents(tt.verdict, events, deadline, 0, tt.branches)
+			if tt.verdict.Status != tt.status || tt.verdict.Confidence != tt.confidence {
+				t.Errorf("Expected %s/%s, got %s/%s", tt.status, tt.confidence, tt.verdict.Status, tt.verdict.Confidence)
+			}
+			if len(tt.verdict.Evidence) != tt.evidence {
+				t.Fatalf("Expected %d evidence items, got %+v", tt.evidence, tt.verdict.Evidence)
+			}
+			for _, evidence := range tt.verdict.Evidence {
+				if evidence.Source != models.EvidenceWebhook || evidence.URL != "https://github.com/team/repo/commit/abc123" {
+					t.Errorf("Unexpected evidence %+v", evidence)
+				}
+			}
+		})
+	}
+
+	onTime := &models.Verdict{Status: models.VerdictUnknown}
+	ApplyWebhookEvents(onTime, events[2:], deadline, 0, nil)
+	if onTime.Status != models.VerdictOnTime || onTime.Confidence != models.ConfidenceHigh || len(onTime.Evidence) != 1 {
+		t.Errorf("Expected a single on-time webhook push, got %+v", onTime)
+	}
+}
+
+func TestApplyWebhookEvents_Grace(t *testing.T) {
+	deadline := time.Date(2025, 9, 30, 16, 0, 0, 0, time.UTC)
+	push := &models.UnifiedEvent{
+		BaseEvent: models.BaseEvent{Type: "PushEvent", CreatedAt: "2025-09-30T16:10:00Z"},
+		Payload:   map[string]interface{}{"ref": "refs/heads/main", "after": "abc123"},
+	}
+
+	tests := []struct {
+		name   string
+		grace  time.Duration
+		status string
+	}{
+		{"within grace", 15 * time.Minute, models.VerdictOnTime},
+		{"after grace", 5 * time.Minute, models.VerdictLate},
+		{"no grace", 0, models.VerdictLate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := &models.Verdict{Status: models.VerdictOnTime, Confidence: models.ConfidenceHigh}
+			ApplyWebhookEvents(verdict, []*models.UnifiedEvent{push}, deadline, tt.grace, nil)
+			if verdict.Status != tt.status {
+				t.Errorf("Expected %s, got %s", tt.status, verdict.Status)
+			}
+		})
+	}
+}
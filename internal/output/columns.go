@@ -0,0 +1,164 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
+)
+
+// RecordHeaders 每个仓库一行的结果表的固定列，与批量处理的仓库明细相同
+// 之后依次为 ResultColumns 中的列；表头不随语言变化（重新处理时按表头查找已有的列），只有取值按语言显示
+var RecordHeaders = []string{"行号", "姓名", "队伍", "仓库地址", "平台", "仓库", "是否可访问", "是否准时提交"}
+
+// ResultColumn 从分析结果计算的结果列（批量处理中的可选结果列）
+type ResultColumn struct {
+	// Key 列名称（--extra-columns 中使用），Header 为表头，Usage 为说明
+	Key    string
+	Header string
+	Usage  string
+	// Value 计算列的值，时间按 loc 显示
+	Value func(result *models.AnalysisResult, loc *time.Location) string
+}
+
+// ResultColumns 所有结果列，按写入表格的顺序排列
+var ResultColumns = []ResultColumn{
+	{"last_push_before_deadline", "截止前最后推送", "time of the last push before the deadline", func(r *models.AnalysisResult, loc *time.Location) string {
+		return eventTime(r.LastBeforeDeadline, loc)
+	}},
+	{"earliest_push", "最早推送时间", "time of the earliest push in the recent events", func(r *models.AnalysisResult, loc *time.Location) string {
+		return eventTime(r.EarliestCodeEvent, loc)
+	}},
+	{"pushes_before_start", "开始前推送次数", "number of pushes before the contest start (with --start)", func(r *models.AnalysisResult, _ *time.Location) string {
+		if r.EarliestCodeEvent == nil {
+			return ""
+		}
+		return strconv.Itoa(r.PushesBeforeStart)
+	}},
+	{"created_at", "仓库创建时间", "repository creation time", func(r *models.AnalysisResult, loc *time.Location) string {
+		if r.Repository == nil || r.Repository.CreatedAt == "" {
+			return ""
+		}
+		return cellTime(r.Repository.CreatedAt, loc)
+	}},
+	{"last_push", "最后推送时间", "time of the last push", func(r *models.AnalysisResult, loc *time.Location) string {
+		return eventTime(r.LastCodeEvent, loc)
+	}},
+	{"actor", "推送者", "login of the last pusher", func(r *models.AnalysisResult, _ *time.Location) string {
+		if r.LastCodeEvent != nil {
+			return r.LastCodeEvent.ActorLogin
+		}
+		return ""
+	}},
+	{"branch", "推送分支", "branch of the last push", func(r *models.AnalysisResult, _ *time.Location) string {
+		branch, _ := monitor.PushRef(r.LastCodeEvent)
+		return branch
+	}},
+	{"head_sha", "HEAD SHA", "head commit SHA of the last push", func(r *models.AnalysisResult, _ *time.Location) string {
+		_, sha := monitor.PushRef(r.LastCodeEvent)
+		return sha
+	}},
+	{"late_pushes", "截止后推送次数", "number of pushes after the deadline", func(r *models.AnalysisResult, _ *time.Location) string {
+		if r.SubmittedBefore == nil {
+			return ""
+		}
+		return strconv.Itoa(r.LatePushes)
+	}},
+	{"time_difference", "时间差", "time between the last push and the deadline", func(r *models.AnalysisResult, _ *time.Location) string {
+		if r.TimeDifferenceSeconds != nil {
+			return i18n.TimeDifference(*r.TimeDifferenceSeconds)
+		}
+		return r.TimeDifference
+	}},
+	{"events_checked", "检查事件数", "number of repository events checked", func(r *models.AnalysisResult, _ *time.Location) string {
+		// 没有分析结果（如仓库不可访问）时留空
+		if !r.Found && r.EventsChecked == 0 {
+			return ""
+		}
+		return strconv.Itoa(r.EventsChecked)
+	}},
+	{"lateness_tier", "迟交档位", "lateness tier of the last push (on_time, grace, late or a penalty tier)", func(r *models.AnalysisResult, _ *time.Location) string {
+		if r.Lateness == nil {
+			return ""
+		}
+		return r.Lateness.Tier
+	}},
+	{"verdict", "结论", "verdict and confidence, e.g. on_time (high)", func(r *models.AnalysisResult, _ *time.Location) string {
+		status := verdictStatus(r.Verdict)
+		if r.Verdict == nil || r.Verdict.Confidence == "" {
+			return status
+		}
+		return fmt.Sprintf("%s (%s)", status, r.Verdict.Confidence)
+	}},
+	{"evidence", "依据", "evidence of the verdict: source, time and URL", func(r *models.AnalysisResult, loc *time.Location) string {
+		if r.Verdict == nil {
+			return ""
+		}
+		lines := make([]string, 0, len(r.Verdict.Evidence))
+		for _, e := range r.Verdict.Evidence {
+			lines = append(lines, strings.TrimSpace(strings.Join([]string{e.Source, cellTime(e.Timestamp, loc), e.URL}, " ")))
+		}
+		return strings.Join(lines, "\n")
+	}},
+	{"flags", "仓库标记", "repository flags (created_before_start, pushed_before_start, private, fork, archived)", func(r *models.AnalysisResult, _ *time.Location) string {
+		return strings.Join(r.Flags, ", ")
+	}},
+	{"error_category", "错误类型", "error category (not_found, forbidden, rate_limited, network, ...)", func(r *models.AnalysisResult, _ *time.Location) string {
+		return r.ErrorCode
+	}},
+}
+
+// FindResultColumn 按名称查找结果列，不存在时返回 nil
+func FindResultColumn(key string) *ResultColumn {
+	for i := range ResultColumns {
+		if ResultColumns[i].Key == key {
+			return &ResultColumns[i]
+		}
+	}
+	return nil
+}
+
+// Records 生成每个仓库一行的结果表（第一行为表头）：RecordHeaders 中的列和所有结果列
+// 行号为 0 时留空；是否可访问和是否准时提交按结论和可信度给出，文字使用当前语言
+func Records(entries []SummaryEntry, loc *time.Location) [][]string {
+	headers := append([]string(nil), RecordHeaders...)
+	for _, c := range ResultColumns {
+		headers = append(headers, c.Header)
+	}
+
+	records := [][]string{headers}
+	for _, entry := range entries {
+		result := entry.Result
+		if result == nil {
+			result = &models.AnalysisResult{}
+		}
+		row := ""
+		if entry.Row > 0 {
+			row = strconv.Itoa(entry.Row)
+		}
+		access, submission := RecordStatus(result)
+		record := []string{row, entry.Name, entry.Team, entry.URL, string(entry.Platform), entry.Repository, access, submission}
+		for _, c := range ResultColumns {
+			record = append(record, c.Value(result, loc))
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// eventTime 按 loc 显示事件时间，没有事件时为空
+func eventTime(event *models.UnifiedEvent, loc *time.Location) string {
+	if event == nil {
+		return ""
+	}
+	return cellTime(event.CreatedAt, loc)
+}
+
+// cellTime 同时按 loc（比赛时区）和 UTC 显示 RFC3339 时间（见 monitor.DualTime），无法解析时原样返回
+func cellTime(value string, loc *time.Location) string {
+	return monitor.FormatDualTime(value, loc)
+}
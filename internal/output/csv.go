@@ -0,0 +1,78 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// recordsSheet xlsx 结果的工作表名称
+const recordsSheet = "结果"
+
+// CSVFormatter CSV 格式化器，每个仓库一行（列见 Records）
+type CSVFormatter struct {
+	// Location 时间的显示时区
+	Location *time.Location
+}
+
+// Format 输出表头和单个仓库的一行
+func (c *CSVFormatter) Format(w io.Writer, result *models.AnalysisResult) error {
+	return c.FormatSummary(w, []SummaryEntry{checkEntry(result)})
+}
+
+// FormatSummary 输出表头和每个仓库的一行
+func (c *CSVFormatter) FormatSummary(w io.Writer, entries []SummaryEntry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(Records(entries, c.Location)); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// XLSXFormatter Excel 格式化器，把每个仓库一行的结果表写成一个工作簿
+type XLSXFormatter struct {
+	// Location 时间的显示时区
+	Location *time.Location
+}
+
+// Format 输出只有单个仓库的工作簿
+func (x *XLSXFormatter) Format(w io.Writer, result *models.AnalysisResult) error {
+	return x.FormatSummary(w, []SummaryEntry{checkEntry(result)})
+}
+
+// FormatSummary 输出包含所有仓库的工作簿
+func (x *XLSXFormatter) FormatSummary(w io.Writer, entries []SummaryEntry) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName(file.GetSheetName(0), recordsSheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+	for i, record := range Records(entries, x.Location) {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		row := make([]interface{}, len(record))
+		for j, value := range record {
+			row[j] = value
+		}
+		if err := file.SetSheetRow(recordsSheet, cell, &row); err != nil {
+			return fmt.Errorf("failed to write row %d: %w", i+1, err)
+		}
+	}
+	// 冻结表头
+	if err := file.SetPanes(recordsSheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return fmt.Errorf("failed to freeze header: %w", err)
+	}
+
+	if _, err := file.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
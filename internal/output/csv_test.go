@@ -0,0 +1,103 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// recordEntries 一个超时仓库和一个不可访问的仓库
+func recordEntries() []SummaryEntry {
+	return []SummaryEntry{
+		{Name: "Alice", Team: "A", Repository: "alice/demo", Platform: models.PlatformGitHub, Result: lateResult()},
+		{Repository: "bob/gone", Platform: models.PlatformGitee, Result: &models.AnalysisResult{
+			Deadline: "2025-09-30T15:59:00Z",
+			Verdict:  &models.Verdict{Status: models.VerdictInaccessible, Confidence: models.ConfidenceHigh},
+		}},
+	}
+}
+
+func TestRecords(t *testing.T) {
+	records := Records(recordEntries(), time.UTC)
+	if len(records) != 3 || len(records[0]) != len(RecordHeaders)+len(ResultColumns) {
+		t.Fatalf("Expected a header and two rows with all columns, got %v", records)
+	}
+	for _, record := range records[1:] {
+		if len(record) != len(records[0]) {
+			t.Errorf("Row length %d differs from header length %d", len(record), len(records[0]))
+		}
+	}
+
+	column := func(header string) int {
+		for i, h := range records[0] {
+			if h == header {
+				return i
+			}
+		}
+		t.Fatalf("Missing column %s", header)
+		return -1
+	}
+	late := records[1]
+	for header, want := range map[string]string{
+		"姓名":     "Alice",
+		"平台":     "github",
+		"仓库":     "alice/demo",
+		"是否可访问":  "Accessible",
+		"是否准时提交": "Late",
+		"最后推送时间": "2025-09-30 17:00:00 UTC",
+		"结论":     "late (high)",
+		"仓库创建时间": "2025-09-01 00:00:00 UTC",
+	} {
+		if got := late[column(header)]; got != want {
+			t.Errorf("Expected %s = %q, got %q", header, want, got)
+		}
+	}
+
+	gone := records[2]
+	if gone[column("是否可访问")] != "Inaccessible" || gone[column("是否准时提交")] != "" || gone[column("检查事件数")] != "" {
+		t.Errorf("Unexpected inaccessible row: %v", gone)
+	}
+}
+
+func TestCSVFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	f := &CSVFormatter{Location: time.UTC}
+	if err := f.FormatSummary(&buf, recordEntries()); err != nil {
+		t.Fatalf("FormatSummary failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "行号" || records[2][5] != "bob/gone" {
+		t.Errorf("Unexpected CSV records: %v", records)
+	}
+}
+
+func TestXLSXFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	f := &XLSXFormatter{Location: time.UTC}
+	if err := f.Format(&buf, lateResult()); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("Invalid xlsx: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(recordsSheet)
+	if err != nil {
+		t.Fatalf("Failed to read sheet: %v", err)
+	}
+	if len(rows) != 2 || rows[1][3] != "https://github.com/alice/demo" || rows[1][5] != "alice/demo" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+}
@@ -3,81 +3,241 @@ package output
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
 	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
 )
 
-// Formatter 输出格式化器接口
+// Formatter 输出格式化器接口，结果写入 w
+// 需要逐个输出结果（如批量处理时每处理完一行输出一次）时使用 NewStream
 type Formatter interface {
-	Format(result *models.AnalysisResult) error
+	// Format 输出单个分析结果
+	Format(w io.Writer, result *models.AnalysisResult) error
+	// FormatSummary 输出一组仓库的结果（check 多个仓库、批量汇总），如 csv 只输出一次表头
+	FormatSummary(w io.Writer, entries []SummaryEntry) error
 }
 
-// NewFormatter 创建格式化器
-func NewFormatter(format string) Formatter {
+// NewFormatter 创建格式化器，loc 为表格中时间的显示时区（同时显示 UTC）
+func NewFormatter(format string, loc *time.Location) Formatter {
 	switch format {
 	case "json":
 		return &JSONFormatter{}
+	case "markdown":
+		return &MarkdownFormatter{Location: loc}
+	case "html":
+		return &HTMLFormatter{Location: loc}
+	case "csv":
+		return &CSVFormatter{Location: loc}
+	case "xlsx":
+		return &XLSXFormatter{Location: loc}
 	case "table":
 		fallthrough
 	default:
-		return &TableFormatter{}
+		return &TableFormatter{Location: loc}
 	}
 }
 
 // TableFormatter 表格格式化器
-type TableFormatter struct{}
+type TableFormatter struct {
+	// Location 时间的显示时区，为空时只显示 UTC
+	Location *time.Location
+}
+
+// formatTime 按显示时区和 UTC 显示 RFC3339 时间
+func (t *TableFormatter) formatTime(value string) string {
+	return monitor.FormatDualTime(value, t.Location)
+}
 
 // Format 格式化为表格输出
-func (t *TableFormatter) Format(result *models.AnalysisResult) error {
+func (t *TableFormatter) Format(w io.Writer, result *models.AnalysisResult) error {
 	if !result.Found {
-		fmt.Printf("❌ No code events found\n")
-		fmt.Printf("📊 Events checked: %d\n", result.EventsChecked)
+		fmt.Fprintf(w, "❌ %s\n", i18n.T(i18n.MsgTableNoEvents))
+		fmt.Fprintf(w, "📊 %s\n", i18n.T(i18n.MsgTableEventsChecked, result.EventsChecked))
 		if result.Error != "" {
-			fmt.Printf("❗ Error: %s\n", result.Error)
+			fmt.Fprintf(w, "❗ %s\n", i18n.T(i18n.MsgTableError, result.Error))
+		}
+		if result.EventDescription != "" {
+			fmt.Fprintf(w, "📝 %s\n", result.EventDescription)
 		}
+		printStatus(w, result)
+		t.printBranchCommits(w, result)
+		t.printVerdict(w, result)
+		t.printRepository(w, result)
 		return nil
 	}
 
-	fmt.Printf("✅ Code event found\n")
-	fmt.Printf("📊 Events checked: %d\n", result.EventsChecked)
+	fmt.Fprintf(w, "✅ %s\n", i18n.T(i18n.MsgTableFound))
+	fmt.Fprintf(w, "📊 %s\n", i18n.T(i18n.MsgTableEventsChecked, result.EventsChecked))
 
 	if result.EventDescription != "" {
-		fmt.Printf("📝 %s\n", result.EventDescription)
+		fmt.Fprintf(w, "📝 %s\n", result.EventDescription)
 	}
 
-	if result.SubmittedBefore != nil {
-		if *result.SubmittedBefore {
-			fmt.Printf("⏰ Status: ✅ Submitted before deadline\n")
-		} else {
-			fmt.Printf("⏰ Status: ❌ Submitted after deadline\n")
-		}
-	}
+	printStatus(w, result)
 
 	if result.TimeDifference != "" {
-		fmt.Printf("📅 Time difference: %s\n", result.TimeDifference)
+		fmt.Fprintf(w, "📅 %s\n", i18n.T(i18n.MsgTableTimeDifference, result.TimeDifference))
+	}
+	if result.EarliestCodeEvent != nil {
+		fmt.Fprintf(w, "🐣 %s\n", i18n.T(i18n.MsgTableEarliest, result.EarliestCodeEvent.Type, t.formatTime(result.EarliestCodeEvent.CreatedAt)))
+	}
+	if result.PushesBeforeStart > 0 {
+		fmt.Fprintf(w, "⚠️  %s\n", i18n.T(i18n.MsgTableBeforeStart, result.PushesBeforeStart, result.CommitsBeforeStart))
 	}
 
 	// 显示事件详情表格
 	if result.LastCodeEvent != nil {
-		fmt.Printf("\n📋 Last Code Event Details:\n")
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Field", "Value"})
+		fmt.Fprintf(w, "\n📋 %s\n", i18n.T(i18n.MsgTableLastEvent))
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{i18n.T(i18n.MsgTableField), i18n.T(i18n.MsgTableValue)})
 		table.SetBorder(false)
 		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 		table.SetAlignment(tablewriter.ALIGN_LEFT)
 
-		table.Append([]string{"Event ID", result.LastCodeEvent.ID})
-		table.Append([]string{"Event Type", result.LastCodeEvent.Type})
-		table.Append([]string{"Created At", result.LastCodeEvent.CreatedAt})
-		table.Append([]string{"Actor", result.LastCodeEvent.ActorLogin})
-		table.Append([]string{"Repository", result.LastCodeEvent.RepoName})
+		table.Append([]string{i18n.T(i18n.MsgTableEventID), result.LastCodeEvent.ID})
+		table.Append([]string{i18n.T(i18n.MsgTableEventType), result.LastCodeEvent.Type})
+		table.Append([]string{i18n.T(i18n.MsgTableCreatedAt), t.formatTime(result.LastCodeEvent.CreatedAt)})
+		table.Append([]string{i18n.T(i18n.MsgTableActor), result.LastCodeEvent.ActorLogin})
+		table.Append([]string{i18n.T(i18n.MsgTableRepo), result.LastCodeEvent.RepoName})
 
 		table.Render()
 	}
 
+	t.printVerdict(w, result)
+	t.printRepository(w, result)
+	return nil
+}
+
+// printStatus 输出是否准时提交，依据提交时间判断时提示可信度低
+func printStatus(w io.Writer, result *models.AnalysisResult) {
+	if result.SubmittedBefore == nil {
+		return
+	}
+	if *result.SubmittedBefore {
+		fmt.Fprintf(w, "⏰ %s\n", i18n.T(i18n.MsgTableOnTime))
+	} else {
+		fmt.Fprintf(w, "⏰ %s\n", i18n.T(i18n.MsgTableLate))
+	}
+	if result.Confidence == models.ConfidenceLow {
+		fmt.Fprintf(w, "⚠️  %s\n", i18n.T(i18n.MsgTableLowConfidence))
+	}
+	if lateness := result.Lateness; lateness != nil {
+		fmt.Fprintf(w, "⏳ %s\n", i18n.T(i18n.MsgTableLateness, lateness.Tier, strconv.FormatFloat(lateness.Penalty, 'f', -1, 64)))
+	}
+}
+
+// printBranchCommits 输出各分支截止时间前后最新的提交
+func (t *TableFormatter) printBranchCommits(w io.Writer, result *models.AnalysisResult) {
+	if len(result.BranchCommits) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n🌿 %s\n", i18n.T(i18n.MsgTableBranchCommits))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{i18n.T(i18n.MsgTableBranch), i18n.T(i18n.MsgTableBeforeDeadline), i18n.T(i18n.MsgTableAfterDeadline)})
+	table.SetBorder(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	commitCell := func(commit *models.Commit) string {
+		if commit == nil {
+			return "-"
+		}
+		sha := commit.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		return sha + " " + t.formatTime(commit.CommittedAt)
+	}
+	for _, branch := range result.BranchCommits {
+		table.Append([]string{branch.Branch, commitCell(branch.Before), commitCell(branch.After)})
+	}
+	table.Render()
+}
+
+// printVerdict 输出结构化的结论、可信度和依据
+func (t *TableFormatter) printVerdict(w io.Writer, result *models.AnalysisResult) {
+	verdict := result.Verdict
+	if verdict == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "\n🧾 %s", i18n.T(i18n.MsgTableVerdict, verdict.Status))
+	if verdict.Confidence != "" {
+		fmt.Fprint(w, i18n.T(i18n.MsgTableConfidence, verdict.Confidence))
+	}
+	fmt.Fprintf(w, "\n")
+	if len(verdict.Evidence) == 0 {
+		return
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{i18n.T(i18n.MsgTableSource), i18n.T(i18n.MsgTableTimestamp), i18n.T(i18n.MsgTableDescription), i18n.T(i18n.MsgTableURL)})
+	table.SetBorder(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, e := range verdict.Evidence {
+		table.Append([]string{e.Source, t.formatTime(e.Timestamp), e.Description, e.URL})
+	}
+	table.Render()
+}
+
+// printRepository 输出仓库信息和标记
+func (t *TableFormatter) printRepository(w io.Writer, result *models.AnalysisResult) {
+	repo := result.Repository
+	if repo == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "\n📦 %s\n", i18n.T(i18n.MsgTableRepository))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{i18n.T(i18n.MsgTableField), i18n.T(i18n.MsgTableValue)})
+	table.SetBorder(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	table.Append([]string{i18n.T(i18n.MsgTableVisibility), repo.Visibility})
+	table.Append([]string{i18n.T(i18n.MsgTableCreatedAt), t.formatTime(repo.CreatedAt)})
+	table.Append([]string{i18n.T(i18n.MsgTablePushedAt), t.formatTime(repo.PushedAt)})
+	table.Append([]string{i18n.T(i18n.MsgTableDefaultBranch), repo.DefaultBranch})
+	if repo.Fork {
+		table.Append([]string{i18n.T(i18n.MsgTableForkOf), repo.Parent})
+	}
+	table.Append([]string{i18n.T(i18n.MsgTableArchived), fmt.Sprintf("%t", repo.Archived)})
+	if repo.Size > 0 {
+		table.Append([]string{i18n.T(i18n.MsgTableSize), fmt.Sprintf("%d KB", repo.Size)})
+	}
+	if repo.License != "" {
+		table.Append([]string{i18n.T(i18n.MsgTableLicense), repo.License})
+	}
+	table.Render()
+
+	if len(result.Flags) > 0 {
+		fmt.Fprintf(w, "🚩 %s\n", i18n.T(i18n.MsgTableFlags, strings.Join(result.Flags, ", ")))
+	}
+}
+
+// FormatSummary 依次输出每个仓库的表格
+func (t *TableFormatter) FormatSummary(w io.Writer, entries []SummaryEntry) error {
+	for i, entry := range entries {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "🔍 %s\n", entry.Repository)
+		if entry.Result == nil {
+			continue
+		}
+		if err := t.Format(w, entry.Result); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -85,8 +245,18 @@ func (t *TableFormatter) Format(result *models.AnalysisResult) error {
 type JSONFormatter struct{}
 
 // Format 格式化为 JSON 输出
-func (j *JSONFormatter) Format(result *models.AnalysisResult) error {
-	encoder := json.NewEncoder(os.Stdout)
+func (j *JSONFormatter) Format(w io.Writer, result *models.AnalysisResult) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(result)
-}
\ No newline at end of file
+}
+
+// FormatSummary 输出所有仓库组成的 JSON 数组
+func (j *JSONFormatter) FormatSummary(w io.Writer, entries []SummaryEntry) error {
+	if entries == nil {
+		entries = []SummaryEntry{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+)
+
+func TestTableFormatter_Writer(t *testing.T) {
+	var buf bytes.Buffer
+	f := &TableFormatter{Location: time.UTC}
+	if err := f.Format(&buf, lateResult()); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"✅ Code event found", "🧾 Verdict: late (confidence: high)", "📦 Repository Details:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := f.FormatSummary(&buf, []SummaryEntry{{Repository: "a/one", Result: lateResult()}, {Repository: "b/two", Result: lateResult()}}); err != nil {
+		t.Fatalf("FormatSummary failed: %v", err)
+	}
+	if strings.Count(buf.String(), "✅ Code event found") != 2 || !strings.Contains(buf.String(), "🔍 b/two") {
+		t.Errorf("Expected both results with headers, got:\n%s", buf.String())
+	}
+}
+
+func TestTableFormatter_Language(t *testing.T) {
+	i18n.SetLanguage(i18n.Chinese)
+	defer i18n.SetLanguage(i18n.English)
+
+	var buf bytes.Buffer
+	if err := (&TableFormatter{Location: time.UTC}).Format(&buf, lateResult()); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"✅ 找到代码提交事件", "🧾 结论：late（可信度：high）", "📦 仓库信息："} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Repository Details") {
+		t.Errorf("Expected no English labels, got:\n%s", out)
+	}
+}
+
+func TestJSONFormatter_FormatSummary(t *testing.T) {
+	var buf bytes.Buffer
+	f := &JSONFormatter{}
+	if err := f.FormatSummary(&buf, []SummaryEntry{{Name: "Alice", Repository: "alice/demo", Result: lateResult()}}); err != nil {
+		t.Fatalf("FormatSummary failed: %v", err)
+	}
+
+	var entries []SummaryEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("Expected a JSON array, got %s: %v", buf.String(), err)
+	}
+	if len(entries) != 1 || entries[0].Name != "Alice" || entries[0].Result.Verdict.Status != "late" {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+
+	buf.Reset()
+	if err := f.FormatSummary(&buf, nil); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected an empty array, got %q (%v)", buf.String(), err)
+	}
+}
@@ -0,0 +1,126 @@
+package output
+
+import (
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// HTMLFormatter HTML 格式化器，输出不依赖外部资源（样式内联）的单个页面
+type HTMLFormatter struct {
+	// Location 时间的显示时区
+	Location *time.Location
+}
+
+// htmlPage 页面模板的数据
+type htmlPage struct {
+	Lang    i18n.Lang
+	Title   string
+	Counts  string
+	Summary bool
+	// WithTrack、WithPenalty 汇总表是否显示赛道列和扣分列
+	WithTrack   bool
+	WithPenalty bool
+	Entries     []reportEntry
+}
+
+// Format 输出单个仓库的结论、摘要和时间线
+func (h *HTMLFormatter) Format(w io.Writer, result *models.AnalysisResult) error {
+	entry := newReportEntry(checkEntry(result), h.Location)
+	return h.render(w, htmlPage{
+		Title:   i18n.T(i18n.MsgReportCheckTitle, entry.Repository),
+		Entries: []reportEntry{entry},
+	})
+}
+
+// FormatSummary 输出批量处理的汇总表，以及每个仓库可展开的时间线
+func (h *HTMLFormatter) FormatSummary(w io.Writer, entries []SummaryEntry) error {
+	page := htmlPage{
+		Title:   i18n.T(i18n.MsgReportSummaryTitle),
+		Summary: true,
+		Entries: make([]reportEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		page.Entries[i] = newReportEntry(entry, h.Location)
+		page.WithTrack = page.WithTrack || page.Entries[i].Track != ""
+		page.WithPenalty = page.WithPenalty || page.Entries[i].Penalty != ""
+	}
+	onTime, late, other := summaryCounts(page.Entries)
+	page.Counts = i18n.T(i18n.MsgReportCounts, len(entries), onTime, late, other)
+	return h.render(w, page)
+}
+
+// render 按页面模板输出
+func (h *HTMLFormatter) render(w io.Writer, page htmlPage) error {
+	page.Lang = i18n.Language()
+	return htmlTemplate.Execute(w, page)
+}
+
+// htmlTemplate 页面模板，样式内联，不引用外部脚本、样式和字体
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"t": func(key string) string { return i18n.T(key) },
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; margin: 2rem; color: #1f2328; }
+table { border-collapse: collapse; margin: 0.5rem 0 1rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+a { color: #0969da; }
+.badge { display: inline-block; padding: 0.1rem 0.5rem; border-radius: 1rem; font-weight: 600; color: #fff; background: #6e7781; }
+.badge-on_time { background: #1a7f37; }
+.badge-late { background: #cf222e; }
+.badge-unknown { background: #bf8700; }
+.badge-empty { background: #8c959f; }
+.badge-inaccessible { background: #24292f; }
+.confidence { color: #57606a; font-size: 0.9em; }
+.facts { list-style: none; padding: 0; }
+details { margin: 0.5rem 0; }
+summary { cursor: pointer; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Summary}}
+<p>{{.Counts}}</p>
+<table>
+<tr><th>{{t "report.row"}}</th><th>{{t "report.name"}}</th><th>{{t "report.team"}}</th>{{if .WithTrack}}<th>{{t "report.track"}}</th>{{end}}<th>{{t "report.repository"}}</th><th>{{t "report.verdict"}}</th><th>{{t "report.last_push"}}</th><th>{{t "report.time_difference"}}</th>{{if .WithPenalty}}<th>{{t "report.penalty"}}</th>{{end}}</tr>
+{{- range .Entries}}
+<tr><td>{{.Row}}</td><td>{{.Name}}</td><td>{{.Team}}</td>{{if $.WithTrack}}<td>{{.Track}}</td>{{end}}<td>{{template "repo" .}}</td><td>{{template "badge" .}}</td><td>{{.LastPush}}</td><td>{{.TimeDifference}}</td>{{if $.WithPenalty}}<td>{{.Penalty}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- range .Entries}}{{if .Timeline}}
+<details>
+<summary>{{.Repository}} {{template "badge" .}}</summary>
+{{template "timeline" .}}
+</details>
+{{- end}}{{end}}
+{{- else}}{{range .Entries}}
+<p>{{template "repo" .}} {{template "badge" .}}</p>
+<ul class="facts">
+{{- if .LastPush}}<li>{{t "report.last_push"}}: {{.LastPush}}</li>{{end}}
+{{- if .TimeDifference}}<li>{{t "report.time_difference"}}: {{.TimeDifference}}</li>{{end}}
+{{- if .Penalty}}<li>{{t "report.penalty"}}: {{.Penalty}}</li>{{end}}
+{{- if .Flags}}<li>{{t "report.flags"}}: {{.Flags}}</li>{{end}}
+</ul>
+<h2>{{t "report.timeline"}}</h2>
+{{template "timeline" .}}
+{{- end}}{{end}}
+</body>
+</html>
+{{define "repo"}}{{if .URL}}<a href="{{.URL}}">{{.Repository}}</a>{{else}}{{.Repository}}{{end}}{{end}}
+{{- define "badge"}}<span class="badge badge-{{.Verdict}}">{{.VerdictLabel}}</span>{{if .ConfidenceLabel}} <span class="confidence">{{.ConfidenceLabel}}</span>{{end}}{{end}}
+{{- define "timeline"}}{{if .Timeline}}<table>
+<tr><th>{{t "report.time"}}</th><th>{{t "report.event"}}</th><th>{{t "report.link"}}</th></tr>
+{{- range .Timeline}}
+<tr><td>{{.Time}}</td><td>{{.Label}}</td><td>{{if .URL}}<a href="{{.URL}}">{{t "report.view"}}</a>{{end}}</td></tr>
+{{- end}}
+</table>{{else}}<p>{{t "report.no_events"}}</p>{{end}}{{end}}
+`))
@@ -0,0 +1,158 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// verdictEmoji 结论徽章前的标记
+var verdictEmoji = map[string]string{
+	models.VerdictOnTime:       "🟢",
+	models.VerdictLate:         "🔴",
+	models.VerdictUnknown:      "🟡",
+	models.VerdictEmpty:        "⚪",
+	models.VerdictInaccessible: "⚫",
+}
+
+// MarkdownFormatter Markdown 格式化器，输出可以直接发布的结果帖子
+type MarkdownFormatter struct {
+	// Location 时间的显示时区
+	Location *time.Location
+}
+
+// Format 输出单个仓库的结论、摘要和时间线
+func (m *MarkdownFormatter) Format(w io.Writer, result *models.AnalysisResult) error {
+	entry := newReportEntry(checkEntry(result), m.Location)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", i18n.T(i18n.MsgReportCheckTitle, markdownLink(entry.Repository, entry.URL)))
+	fmt.Fprintf(&b, "**%s:** %s\n\n", i18n.T(i18n.MsgReportVerdict), markdownBadge(entry))
+	writeMarkdownFacts(&b, entry)
+	writeMarkdownTimeline(&b, entry)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// FormatSummary 输出批量处理的汇总表，以及每个仓库的时间线
+func (m *MarkdownFormatter) FormatSummary(w io.Writer, entries []SummaryEntry) error {
+	views := make([]reportEntry, len(entries))
+	withTrack, withPenalty := false, false
+	for i, entry := range entries {
+		views[i] = newReportEntry(entry, m.Location)
+		withTrack = withTrack || views[i].Track != ""
+		withPenalty = withPenalty || views[i].Penalty != ""
+	}
+	onTime, late, other := summaryCounts(views)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", i18n.T(i18n.MsgReportSummaryTitle))
+	fmt.Fprintf(&b, "%s\n\n", i18n.T(i18n.MsgReportCounts, len(views), onTime, late, other))
+
+	headers := []string{i18n.T(i18n.MsgReportRow), i18n.T(i18n.MsgReportName), i18n.T(i18n.MsgReportTeam)}
+	if withTrack {
+		headers = append(headers, i18n.T(i18n.MsgReportTrack))
+	}
+	headers = append(headers, i18n.T(i18n.MsgReportRepository), i18n.T(i18n.MsgReportVerdict), i18n.T(i18n.MsgReportLastPush), i18n.T(i18n.MsgReportTimeDifference))
+	if withPenalty {
+		headers = append(headers, i18n.T(i18n.MsgReportPenalty))
+	}
+	writeMarkdownRow(&b, headers)
+	writeMarkdownRow(&b, strings.Split(strings.Repeat("---,", len(headers)-1)+"---", ","))
+	for _, view := range views {
+		cells := []string{strconv.Itoa(view.Row), markdownEscape(view.Name), markdownEscape(view.Team)}
+		if withTrack {
+			cells = append(cells, markdownEscape(view.Track))
+		}
+		cells = append(cells, markdownLink(view.Repository, view.URL), markdownBadge(view), view.LastPush, markdownEscape(view.TimeDifference))
+		if withPenalty {
+			cells = append(cells, view.Penalty)
+		}
+		writeMarkdownRow(&b, cells)
+	}
+
+	for _, view := range views {
+		if len(view.Timeline) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", markdownLink(view.Repository, view.URL))
+		writeMarkdownTimeline(&b, view)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownFacts 输出最后推送、时间差、扣分和标记
+func writeMarkdownFacts(b *strings.Builder, entry reportEntry) {
+	facts := [][2]string{
+		{i18n.T(i18n.MsgReportLastPush), entry.LastPush},
+		{i18n.T(i18n.MsgReportTimeDifference), markdownEscape(entry.TimeDifference)},
+		{i18n.T(i18n.MsgReportPenalty), entry.Penalty},
+		{i18n.T(i18n.MsgReportFlags), markdownEscape(entry.Flags)},
+	}
+	written := false
+	for _, fact := range facts {
+		if fact[1] == "" {
+			continue
+		}
+		fmt.Fprintf(b, "- %s: %s\n", fact[0], fact[1])
+		written = true
+	}
+	if written {
+		b.WriteString("\n")
+	}
+}
+
+// writeMarkdownTimeline 输出时间线表格
+func writeMarkdownTimeline(b *strings.Builder, entry reportEntry) {
+	fmt.Fprintf(b, "### %s\n\n", i18n.T(i18n.MsgReportTimeline))
+	if len(entry.Timeline) == 0 {
+		fmt.Fprintf(b, "%s\n", i18n.T(i18n.MsgReportNoEvents))
+		return
+	}
+	writeMarkdownRow(b, []string{i18n.T(i18n.MsgReportTime), i18n.T(i18n.MsgReportEvent), i18n.T(i18n.MsgReportLink)})
+	writeMarkdownRow(b, []string{"---", "---", "---"})
+	for _, item := range entry.Timeline {
+		link := ""
+		if item.URL != "" {
+			link = markdownLink(i18n.T(i18n.MsgReportView), item.URL)
+		}
+		writeMarkdownRow(b, []string{item.Time, markdownEscape(item.Label), link})
+	}
+}
+
+// writeMarkdownRow 输出一行表格
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
+
+// markdownBadge 结论徽章，如 "🔴 **Late** · high confidence"
+func markdownBadge(entry reportEntry) string {
+	badge := verdictEmoji[entry.Verdict] + " **" + markdownEscape(entry.VerdictLabel) + "**"
+	if entry.ConfidenceLabel != "" {
+		badge += " · " + entry.ConfidenceLabel
+	}
+	return badge
+}
+
+// markdownLink 生成链接，没有地址时只输出文字
+func markdownLink(text, url string) string {
+	text = markdownEscape(text)
+	if url == "" {
+		return text
+	}
+	return "[" + text + "](" + strings.ReplaceAll(url, ")", "%29") + ")"
+}
+
+// markdownReplacer 表格和链接中有特殊含义的字符
+var markdownReplacer = strings.NewReplacer("|", `\|`, "[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`, "\n", "<br>")
+
+// markdownEscape 转义表格和链接中有特殊含义的字符
+func markdownEscape(s string) string {
+	return markdownReplacer.Replace(s)
+}
@@ -0,0 +1,167 @@
+package output
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
+)
+
+// SummaryEntry 一组结果中的一个仓库（批量汇总、check 多个仓库）
+type SummaryEntry struct {
+	Sheet string `json:"sheet,omitempty"`
+	Row   int    `json:"row,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Team  string `json:"team,omitempty"`
+	Track string `json:"track,omitempty"`
+	// Repository 仓库全名（owner/repo），URL 为表格中填写的仓库地址
+	Repository string          `json:"repository"`
+	URL        string          `json:"url,omitempty"`
+	Platform   models.Platform `json:"platform,omitempty"`
+	// Result 分析结果，包含结论、仓库信息和标记；仓库不可访问时只有结论
+	Result *models.AnalysisResult `json:"result,omitempty"`
+}
+
+// reportEntry 报告中一个仓库的显示内容，markdown 和 html 共用
+type reportEntry struct {
+	Repository string
+	URL        string
+	Row        int
+	Name       string
+	Team       string
+	Track      string
+	// Verdict 结论（见 models.Verdict*），VerdictLabel 和 ConfidenceLabel 为结论和可信度的文字
+	Verdict         string
+	VerdictLabel    string
+	ConfidenceLabel string
+	LastPush        string
+	TimeDifference  string
+	Penalty         string
+	Flags           string
+	Timeline        []timelineItem
+}
+
+// timelineItem 时间线中的一个事件
+type timelineItem struct {
+	at    time.Time
+	Time  string
+	Label string
+	URL   string
+}
+
+// newReportEntry 整理单个仓库的显示内容，时间按 loc 显示
+func newReportEntry(entry SummaryEntry, loc *time.Location) reportEntry {
+	view := reportEntry{
+		Repository: entry.Repository,
+		URL:        entry.URL,
+		Row:        entry.Row,
+		Name:       entry.Name,
+		Team:       entry.Team,
+		Track:      entry.Track,
+		Verdict:    models.VerdictUnknown,
+	}
+	result := entry.Result
+	if result == nil {
+		view.VerdictLabel = i18n.T(i18n.VerdictPrefix + view.Verdict)
+		return view
+	}
+
+	if repo := result.Repository; repo != nil && repo.HTMLURL != "" {
+		view.URL = repo.HTMLURL
+	}
+	if result.Verdict != nil {
+		view.Verdict = result.Verdict.Status
+		if result.Verdict.Confidence != "" {
+			view.ConfidenceLabel = i18n.T(i18n.ConfidencePrefix + result.Verdict.Confidence)
+		}
+	}
+	view.VerdictLabel = i18n.T(i18n.VerdictPrefix + view.Verdict)
+	if result.LastCodeEvent != nil {
+		view.LastPush = formatReportTime(result.LastCodeEvent.CreatedAt, loc)
+	}
+	if result.TimeDifferenceSeconds != nil {
+		view.TimeDifference = i18n.TimeDifference(*result.TimeDifferenceSeconds)
+	} else {
+		view.TimeDifference = result.TimeDifference
+	}
+	if result.Lateness != nil && result.Lateness.Penalty != 0 {
+		view.Penalty = strconv.FormatFloat(result.Lateness.Penalty, 'f', -1, 64)
+	}
+	view.Flags = strings.Join(result.Flags, ", ")
+	view.Timeline = buildTimeline(result, loc)
+	return view
+}
+
+// buildTimeline 按时间顺序列出仓库创建、最早推送、结论依据（推送、提交）和截止时间
+// 同一时间、同一说明的事件只保留一次，时间无法解析的事件不列出
+func buildTimeline(result *models.AnalysisResult, loc *time.Location) []timelineItem {
+	var items []timelineItem
+	seen := make(map[string]bool)
+	add := func(value, label, url string) {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil || seen[value+"|"+label] {
+			return
+		}
+		seen[value+"|"+label] = true
+		items = append(items, timelineItem{at: t, Time: monitor.DualTime(t, loc), Label: label, URL: url})
+	}
+
+	if repo := result.Repository; repo != nil {
+		add(repo.CreatedAt, i18n.T(i18n.MsgTimelineCreated), repo.HTMLURL)
+	}
+	if event := result.EarliestCodeEvent; event != nil && (result.LastCodeEvent == nil || event.CreatedAt != result.LastCodeEvent.CreatedAt) {
+		add(event.CreatedAt, i18n.T(i18n.MsgTimelineEarliest, event.ActorLogin), "")
+	}
+	if result.Verdict != nil {
+		for _, e := range result.Verdict.Evidence {
+			add(e.Timestamp, e.Description, e.URL)
+		}
+	}
+	add(result.Deadline, i18n.T(i18n.MsgTimelineDeadline), "")
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].at.Before(items[j].at) })
+	return items
+}
+
+// formatReportTime 按 loc 显示 RFC3339 时间（带时区缩写），无法解析时原样返回
+func formatReportTime(value string, loc *time.Location) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil || loc == nil {
+		return value
+	}
+	return t.In(loc).Format(monitor.DisplayLayout + " MST")
+}
+
+// summaryCounts 汇总中准时、超时和其他结论的仓库数
+func summaryCounts(entries []reportEntry) (onTime, late, other int) {
+	for _, entry := range entries {
+		switch entry.Verdict {
+		case models.VerdictOnTime:
+			onTime++
+		case models.VerdictLate:
+			late++
+		default:
+			other++
+		}
+	}
+	return onTime, late, other
+}
+
+// checkEntry 把单个 check 结果包装成汇总条目
+func checkEntry(result *models.AnalysisResult) SummaryEntry {
+	entry := SummaryEntry{Result: result}
+	if result.Repository != nil {
+		entry.URL = result.Repository.HTMLURL
+	}
+	switch {
+	case result.Repository != nil && result.Repository.FullName != "":
+		entry.Repository = result.Repository.FullName
+	case result.LastCodeEvent != nil:
+		entry.Repository = result.LastCodeEvent.RepoName
+	}
+	return entry
+}
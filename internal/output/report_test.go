@@ -0,0 +1,141 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// lateResult 一个超时提交的分析结果，依据为一次推送事件
+func lateResult() *models.AnalysisResult {
+	return &models.AnalysisResult{
+		Found:    true,
+		Deadline: "2025-09-30T15:59:00Z",
+		LastCodeEvent: &models.UnifiedEvent{
+			BaseEvent: models.BaseEvent{Type: "PushEvent", CreatedAt: "2025-09-30T17:00:00Z"},
+			RepoName:  "alice/demo",
+		},
+		EarliestCodeEvent: &models.UnifiedEvent{
+			BaseEvent:  models.BaseEvent{Type: "PushEvent", CreatedAt: "2025-09-20T08:00:00Z"},
+			ActorLogin: "alice",
+		},
+		Repository: &models.Repository{
+			FullName:  "alice/demo",
+			HTMLURL:   "https://github.com/alice/demo",
+			CreatedAt: "2025-09-01T00:00:00Z",
+		},
+		Verdict: &models.Verdict{
+			Status:     models.VerdictLate,
+			Confidence: models.ConfidenceHigh,
+			Evidence: []models.Evidence{{
+				Source:      models.EvidencePushEvent,
+				Timestamp:   "2025-09-30T17:00:00Z",
+				URL:         "https://github.com/alice/demo/compare/abc...def",
+				Description: "PushEvent by alice on main",
+			}},
+		},
+	}
+}
+
+func TestBuildTimeline(t *testing.T) {
+	items := buildTimeline(lateResult(), time.UTC)
+
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.Label
+	}
+	want := "Repository created,Earliest push by alice,Deadline,PushEvent by alice on main"
+	if strings.Join(labels, ",") != want {
+		t.Errorf("Expected timeline %s, got %v", want, labels)
+	}
+	if items[3].URL == "" {
+		t.Error("Expected the push to link to the compare page")
+	}
+}
+
+func TestMarkdownFormatter_Format(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{Location: time.UTC}
+	if err := f.Format(&buf, lateResult()); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"## Submission check: [alice/demo](https://github.com/alice/demo)",
+		"🔴 **Late** · high confidence",
+		"[view](https://github.com/alice/demo/compare/abc...def)",
+		"| 2025-09-30 17:00:00 UTC | PushEvent by alice on main |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownFormatter_FormatSummary(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{Location: time.UTC}
+	entries := []SummaryEntry{
+		{Row: 2, Name: "Alice", Team: "A|B", Repository: "alice/demo", Result: lateResult()},
+		{Row: 3, Name: "Bob", Repository: "bob/gone", URL: "https://github.com/bob/gone",
+			Result: &models.AnalysisResult{Verdict: &models.Verdict{Status: models.VerdictInaccessible}}},
+	}
+	if err := f.FormatSummary(&buf, entries); err != nil {
+		t.Fatalf("FormatSummary failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"2 repositories: 0 on time, 1 late, 1 other",
+		`| 2 | Alice | A\|B | [alice/demo](https://github.com/alice/demo) | 🔴 **Late** · high confidence |`,
+		"| 3 | Bob |  | [bob/gone](https://github.com/bob/gone) | ⚫ **Inaccessible** |",
+		"## [alice/demo](https://github.com/alice/demo)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Track") || strings.Contains(out, "Penalty") {
+		t.Errorf("Expected no track or penalty column, got:\n%s", out)
+	}
+}
+
+func TestHTMLFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	f := &HTMLFormatter{Location: time.UTC}
+	result := lateResult()
+	result.Verdict.Evidence[0].Description = "<script>alert(1)</script>"
+	if err := f.FormatSummary(&buf, []SummaryEntry{{Row: 2, Name: "Alice", Repository: "alice/demo", Result: result}}); err != nil {
+		t.Fatalf("FormatSummary failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`<span class="badge badge-late">Late</span>`,
+		`<a href="https://github.com/alice/demo">alice/demo</a>`,
+		`<a href="https://github.com/alice/demo/compare/abc...def">view</a>`,
+		"&lt;script&gt;",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	// 页面不能依赖外部资源
+	for _, external := range []string{"<script", "<link", "src=", "@import", "url("} {
+		if strings.Contains(out, external) {
+			t.Errorf("Expected a self-contained page, found %q", external)
+		}
+	}
+
+	buf.Reset()
+	if err := f.Format(&buf, lateResult()); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<title>Submission check: alice/demo</title>") {
+		t.Errorf("Expected check title, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// 是否可访问、是否准时提交两列的取值，与语言无关；写入表格时由 StatusText 转换为当前语言的文字
+const (
+	StatusAccessible     = "accessible"
+	StatusInaccessible   = "inaccessible"
+	StatusNoDeadline     = "no_deadline"
+	StatusAnalysisFailed = "analysis_failed"
+	StatusInitialCommit  = "initial_commit"
+	StatusEmptyRepo      = "empty_repository"
+	StatusUndetermined   = "undetermined"
+	StatusOnTime         = "on_time"
+	StatusLate           = "late"
+	// 依据仓库的最后推送时间判断，或推送事件与最后推送时间不一致
+	StatusOnTimeMediumConfidence = "on_time_medium_confidence"
+	StatusLateMediumConfidence   = "late_medium_confidence"
+	// 没有推送事件时按提交时间判断，提交时间可以被伪造
+	StatusOnTimeLowConfidence = "on_time_low_confidence"
+	StatusLateLowConfidence   = "late_low_confidence"
+)
+
+// StatusText 按当前语言返回状态值的文字，空值返回空字符串
+func StatusText(status string) string {
+	if status == "" {
+		return ""
+	}
+	return i18n.T(i18n.StatusPrefix + status)
+}
+
+// ResultStatus 按结论、可信度和错误类型给出是否可访问和是否准时提交两列的取值
+// 批量处理的结果列和仓库明细、check 的 csv/xlsx 输出都使用该函数，同一仓库在各处的取值一致；
+// 不可访问时准时提交列为空
+func ResultStatus(result *models.AnalysisResult) (access, submission string) {
+	switch verdict := result.Verdict; verdictStatus(verdict) {
+	case models.VerdictInaccessible:
+		return StatusInaccessible, ""
+	case models.VerdictEmpty:
+		return StatusAccessible, StatusEmptyRepo
+	case models.VerdictOnTime:
+		return StatusAccessible, withConfidence(StatusOnTime, verdict.Confidence)
+	case models.VerdictLate:
+		return StatusAccessible, withConfidence(StatusLate, verdict.Confidence)
+	}
+
+	// 无法得出结论时给出原因
+	switch result.ErrorCode {
+	case "", models.ErrorInvalidStart, models.ErrorInvalidDeadline, models.ErrorInvalidEventTime, models.ErrorInvalidTime:
+	case models.ErrorNoCodeEvents, models.ErrorNoPushEvents:
+		if result.Deadline != "" {
+			return StatusAccessible, StatusInitialCommit
+		}
+	default:
+		return StatusAccessible, StatusAnalysisFailed
+	}
+	if result.Deadline == "" {
+		return StatusAccessible, StatusNoDeadline
+	}
+	return StatusAccessible, StatusUndetermined
+}
+
+// RecordStatus 按当前语言返回 ResultStatus 两列的文字
+// check 的 csv/xlsx 结果表和批量处理的仓库明细都使用该函数
+func RecordStatus(result *models.AnalysisResult) (access, submission string) {
+	access, submission = ResultStatus(result)
+	return StatusText(access), StatusText(submission)
+}
+
+// withConfidence 按可信度给出准时或超时的状态值
+func withConfidence(status, confidence string) string {
+	switch confidence {
+	case models.ConfidenceMedium:
+		return status + "_medium_confidence"
+	case models.ConfidenceLow:
+		return status + "_low_confidence"
+	default:
+		return status
+	}
+}
@@ -0,0 +1,35 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestResultStatus(t *testing.T) {
+	const deadline = "2025-09-30T15:59:00Z"
+	tests := []struct {
+		name       string
+		result     *models.AnalysisResult
+		access     string
+		submission string
+	}{
+		{"inaccessible", &models.AnalysisResult{Verdict: &models.Verdict{Status: models.VerdictInaccessible}}, StatusInaccessible, ""},
+		{"empty", &models.AnalysisResult{Verdict: &models.Verdict{Status: models.VerdictEmpty}}, StatusAccessible, StatusEmptyRepo},
+		{"on time", &models.AnalysisResult{Deadline: deadline, Verdict: &models.Verdict{Status: models.VerdictOnTime, Confidence: models.ConfidenceHigh}}, StatusAccessible, StatusOnTime},
+		{"late by pushed_at", &models.AnalysisResult{Deadline: deadline, Verdict: &models.Verdict{Status: models.VerdictLate, Confidence: models.ConfidenceMedium}}, StatusAccessible, StatusLateMediumConfidence},
+		{"on time by commit", &models.AnalysisResult{Deadline: deadline, Verdict: &models.Verdict{Status: models.VerdictOnTime, Confidence: models.ConfidenceLow}}, StatusAccessible, StatusOnTimeLowConfidence},
+		{"no push events", &models.AnalysisResult{Deadline: deadline, ErrorCode: models.ErrorNoPushEvents, Verdict: &models.Verdict{Status: models.VerdictUnknown}}, StatusAccessible, StatusInitialCommit},
+		{"no deadline", &models.AnalysisResult{Verdict: &models.Verdict{Status: models.VerdictUnknown}}, StatusAccessible, StatusNoDeadline},
+		{"invalid time", &models.AnalysisResult{Deadline: deadline, ErrorCode: models.ErrorInvalidTime, Verdict: &models.Verdict{Status: models.VerdictUnknown}}, StatusAccessible, StatusUndetermined},
+		{"network error", &models.AnalysisResult{Deadline: deadline, ErrorCode: "network", Verdict: &models.Verdict{Status: models.VerdictUnknown}}, StatusAccessible, StatusAnalysisFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			access, submission := ResultStatus(tt.result)
+			if access != tt.access || submission != tt.submission {
+				t.Errorf("Expected %q/%q, got %q/%q", tt.access, tt.submission, access, submission)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// Stream 逐个输出结果（如批量处理时每处理完一行输出一次），Close 时输出剩余内容
+type Stream interface {
+	Write(entry SummaryEntry) error
+	Close() error
+}
+
+// NewStream 创建把结果逐个写入 w 的结果流
+// csv 只输出一次表头；xlsx、html 和定义了 "summary" 子模板的模板需要全部结果，在 Close 时输出汇总；
+// json 逐个输出 JSON 数组的元素，与 JSONFormatter.FormatSummary 的数组相同；
+// 其余格式（table、markdown）每个结果输出一次。所有格式都输出不可访问的仓库
+func NewStream(f Formatter, w io.Writer) Stream {
+	switch f := f.(type) {
+	case *JSONFormatter:
+		return &jsonStream{w: w}
+	case *CSVFormatter:
+		return &csvStream{formatter: f, writer: csv.NewWriter(w)}
+	case *XLSXFormatter, *HTMLFormatter:
+		return &collectStream{formatter: f, w: w}
+	case *TemplateFormatter:
+		if f.HasSummary() {
+			return &collectStream{formatter: f, w: w}
+		}
+	}
+	return &formatStream{formatter: f, w: w}
+}
+
+// formatStream 每个结果调用一次 Format；表格与 TableFormatter.FormatSummary 一样先输出仓库名
+type formatStream struct {
+	formatter Formatter
+	w         io.Writer
+	started   bool
+}
+
+func (s *formatStream) Write(entry SummaryEntry) error {
+	if table, ok := s.formatter.(*TableFormatter); ok {
+		if s.started {
+			fmt.Fprintln(s.w)
+		}
+		s.started = true
+		return table.FormatSummary(s.w, []SummaryEntry{entry})
+	}
+	if entry.Result == nil {
+		return nil
+	}
+	return s.formatter.Format(s.w, namedResult(entry))
+}
+
+func (s *formatStream) Close() error {
+	return nil
+}
+
+// namedResult 条目的分析结果；没有仓库信息（如不可访问的仓库）时补上条目中的仓库名和地址，供标题和模板中的 repo 使用
+func namedResult(entry SummaryEntry) *models.AnalysisResult {
+	result := entry.Result
+	if result.Repository == nil && entry.Repository != "" {
+		copied := *result
+		copied.Repository = &models.Repository{FullName: entry.Repository, HTMLURL: entry.URL}
+		result = &copied
+	}
+	return result
+}
+
+// jsonStream 逐个输出 JSON 数组的元素，Close 时结束数组
+type jsonStream struct {
+	w       io.Writer
+	started bool
+}
+
+func (s *jsonStream) Write(entry SummaryEntry) error {
+	data, err := json.MarshalIndent(entry, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	separator := ",\n  "
+	if !s.started {
+		separator = "[\n  "
+	}
+	s.started = true
+	_, err = fmt.Fprintf(s.w, "%s%s", separator, data)
+	return err
+}
+
+// Close 没有任何结果时输出空数组
+func (s *jsonStream) Close() error {
+	end := "\n]\n"
+	if !s.started {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(s.w, end)
+	return err
+}
+
+// collectStream 收集所有结果，Close 时调用一次 FormatSummary
+type collectStream struct {
+	formatter Formatter
+	w         io.Writer
+	entries   []SummaryEntry
+}
+
+func (s *collectStream) Write(entry SummaryEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *collectStream) Close() error {
+	return s.formatter.FormatSummary(s.w, s.entries)
+}
+
+// csvStream 第一个结果前输出表头，之后每个结果输出一行
+type csvStream struct {
+	formatter *CSVFormatter
+	writer    *csv.Writer
+	started   bool
+}
+
+func (s *csvStream) Write(entry SummaryEntry) error {
+	records := Records([]SummaryEntry{entry}, s.formatter.Location)
+	if s.started {
+		records = records[1:]
+	}
+	s.started = true
+	if err := s.writer.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// Close 没有任何结果时只输出表头
+func (s *csvStream) Close() error {
+	if s.started {
+		return nil
+	}
+	if err := s.writer.WriteAll(Records(nil, s.formatter.Location)); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestStream_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	stream := NewStream(&CSVFormatter{Location: time.UTC}, &buf)
+	for _, entry := range recordEntries() {
+		if err := stream.Write(entry); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "行号" || records[1][5] != "alice/demo" || records[2][5] != "bob/gone" {
+		t.Errorf("Expected a single header and one row per result, got %v", records)
+	}
+
+	// 没有结果时只有表头
+	buf.Reset()
+	if err := NewStream(&CSVFormatter{}, &buf).Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("Expected only the header, got %q", buf.String())
+	}
+}
+
+func TestStream_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	stream := NewStream(&XLSXFormatter{Location: time.UTC}, &buf)
+	for _, entry := range recordEntries() {
+		if err := stream.Write(entry); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if buf.Len() != 0 {
+		t.Error("Expected the workbook to be written on Close")
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("Invalid xlsx: %v", err)
+	}
+	defer file.Close()
+	if rows, _ := file.GetRows(recordsSheet); len(rows) != 3 {
+		t.Errorf("Expected a header and two rows, got %v", rows)
+	}
+}
+
+func TestStream_Format(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := NewTemplateFormatter("oneline", time.UTC)
+	if err != nil {
+		t.Fatalf("NewTemplateFormatter failed: %v", err)
+	}
+	stream := NewStream(f, &buf)
+	if err := stream.Write(SummaryEntry{Result: lateResult()}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	// 每个结果写入时立即输出
+	if !strings.HasPrefix(buf.String(), "🔴 alice/demo Late") {
+		t.Errorf("Expected the result to be written immediately, got %q", buf.String())
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
+
+func TestStream_JSON(t *testing.T) {
+	entries := recordEntries()
+	second := entries[0]
+	second.Repository = "carol/demo"
+
+	var buf bytes.Buffer
+	stream := NewStream(&JSONFormatter{}, &buf)
+	for _, entry := range []SummaryEntry{entries[0], entries[1], second} {
+		if err := stream.Write(entry); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// 与 check 多个仓库时的 JSON 数组相同，包括不可访问的仓库
+	var want bytes.Buffer
+	if err := (&JSONFormatter{}).FormatSummary(&want, []SummaryEntry{entries[0], entries[1], second}); err != nil {
+		t.Fatalf("FormatSummary failed: %v", err)
+	}
+	if buf.String() != want.String() {
+		t.Errorf("Expected the stream to match FormatSummary:\n%s\ngot:\n%s", want.String(), buf.String())
+	}
+
+	buf.Reset()
+	stream = NewStream(&JSONFormatter{}, &buf)
+	if err := stream.Close(); err != nil || buf.String() != "[]\n" {
+		t.Errorf("Expected an empty array, got %q (%v)", buf.String(), err)
+	}
+}
+
+func TestStream_Inaccessible(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter Formatter
+	}{
+		{"table", &TableFormatter{Location: time.UTC}},
+		{"markdown", &MarkdownFormatter{Location: time.UTC}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			stream := NewStream(tt.formatter, &buf)
+			if err := stream.Write(recordEntries()[1]); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+			if err := stream.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+			out := buf.String()
+			if !strings.Contains(out, "bob/gone") || !strings.Contains(strings.ToLower(out), models.VerdictInaccessible) {
+				t.Errorf("Expected the inaccessible repository to be listed, got:\n%s", out)
+			}
+		})
+	}
+}
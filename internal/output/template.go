@@ -0,0 +1,234 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/i18n"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+	"github.com/luoliwoshang/git-event-monitor/internal/monitor"
+)
+
+// summaryTemplateName 模板中用于批量汇总的子模板名称
+const summaryTemplateName = "summary"
+
+// builtinTemplates 内置模板，--template 可以直接使用这些名称
+var builtinTemplates = map[string]string{
+	// oneline 每个仓库一行
+	"oneline": `{{verdictEmoji .Verdict}} {{repo .}} {{verdictLabel .Verdict}}` +
+		`{{with .LastCodeEvent}} | {{localTime .CreatedAt}}{{end}}` +
+		`{{with .TimeDifference}} | {{.}}{{end}}` +
+		`{{with .Lateness}}{{if .Penalty}} | -{{.Penalty}}{{end}}{{end}}` + "\n",
+	// wechat 适合发到微信群的通知，不使用 markdown 和表格
+	"wechat": `【{{t "report.check_title" (repo .)}}】
+{{t "report.verdict"}}：{{verdictEmoji .Verdict}} {{verdictLabel .Verdict}}
+{{- with .LastCodeEvent}}
+{{t "report.last_push"}}：{{localTime .CreatedAt}}{{with .ActorLogin}}（{{mask .}}）{{end}}{{end}}
+{{- with .TimeDifference}}
+{{t "report.time_difference"}}：{{.}}{{end}}
+{{- with .Deadline}}
+{{t "timeline.deadline"}}：{{localTime .}}{{end}}
+{{define "summary"}}【{{t "report.summary_title"}}】
+{{t "report.counts" .Total .OnTime .Late .Other}}
+{{range .Entries}}
+{{if .Row}}{{.Row}}. {{end}}{{with .Team}}{{.}} {{end}}{{if .Name}}{{mask .Name}}{{else}}{{.Repository}}{{end}}：{{verdictEmoji .Result.Verdict}} {{verdictLabel .Result.Verdict}}
+{{- with .Result.TimeDifference}}（{{.}}）{{end}}
+{{- end}}
+{{end}}`,
+}
+
+// BuiltinTemplates 内置模板的名称
+func BuiltinTemplates() []string {
+	names := make([]string, 0, len(builtinTemplates))
+	for name := range builtinTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TemplateSummary 批量汇总模板的数据
+type TemplateSummary struct {
+	Entries []SummaryEntry
+	// Total 仓库数，OnTime、Late、Other 为准时、超时和其他结论的仓库数
+	Total  int
+	OnTime int
+	Late   int
+	Other  int
+}
+
+// TemplateFormatter 用 text/template 模板输出分析结果
+// 模板以 *models.AnalysisResult 为数据；批量汇总时如果模板定义了 "summary" 子模板，
+// 以 TemplateSummary 为数据执行一次，否则对每个仓库的分析结果执行一次主模板
+type TemplateFormatter struct {
+	Template *template.Template
+	// Location 时间的显示时区
+	Location *time.Location
+}
+
+// NewTemplateFormatter 加载模板，name 为内置模板名称（见 BuiltinTemplates）或模板文件路径
+func NewTemplateFormatter(name string, loc *time.Location) (*TemplateFormatter, error) {
+	if name == "" {
+		return nil, fmt.Errorf("template is required (a file or one of: %s)", strings.Join(BuiltinTemplates(), ", "))
+	}
+	f := &TemplateFormatter{Location: loc}
+
+	text, ok := builtinTemplates[name]
+	if !ok {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		text = string(data)
+	}
+
+	tmpl, err := template.New(name).Funcs(f.funcs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	f.Template = tmpl
+	return f, nil
+}
+
+// Format 以分析结果为数据执行主模板
+func (f *TemplateFormatter) Format(w io.Writer, result *models.AnalysisResult) error {
+	if err := f.Template.Execute(w, result); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+// FormatSummary 执行 "summary" 子模板，没有该子模板时逐个输出每个仓库的分析结果
+func (f *TemplateFormatter) FormatSummary(w io.Writer, entries []SummaryEntry) error {
+	if !f.HasSummary() {
+		for _, entry := range entries {
+			if entry.Result == nil {
+				continue
+			}
+			if err := f.Format(w, entry.Result); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data := TemplateSummary{Entries: entries, Total: len(entries)}
+	for _, entry := range entries {
+		switch entryVerdict(entry.Result) {
+		case models.VerdictOnTime:
+			data.OnTime++
+		case models.VerdictLate:
+			data.Late++
+		default:
+			data.Other++
+		}
+	}
+	if err := f.Template.ExecuteTemplate(w, summaryTemplateName, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+// HasSummary 模板是否定义了 "summary" 子模板
+func (f *TemplateFormatter) HasSummary() bool {
+	return f.Template.Lookup(summaryTemplateName) != nil
+}
+
+// funcs 模板中可用的函数
+func (f *TemplateFormatter) funcs() template.FuncMap {
+	return template.FuncMap{
+		// localTime 按显示时区显示 RFC3339 时间（带时区缩写），dualTime 同时显示 UTC
+		"localTime": func(value string) string { return formatReportTime(value, f.Location) },
+		"dualTime":  func(value string) string { return monitor.FormatDualTime(value, f.Location) },
+		// formatTime 按 Go 时间格式（如 "01-02 15:04"）在显示时区中格式化 RFC3339 时间
+		"formatTime": func(layout, value string) string {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return value
+			}
+			if f.Location != nil {
+				t = t.In(f.Location)
+			}
+			return t.Format(layout)
+		},
+		"inZone":       templateInZone,
+		"duration":     templateDuration,
+		"mask":         maskText,
+		"repo":         func(result *models.AnalysisResult) string { return checkEntry(result).Repository },
+		"verdictLabel": func(v *models.Verdict) string { return i18n.T(i18n.VerdictPrefix + verdictStatus(v)) },
+		"verdictEmoji": func(v *models.Verdict) string { return verdictEmoji[verdictStatus(v)] },
+		"t":            i18n.T,
+		"join":         strings.Join,
+	}
+}
+
+// templateInZone 把 RFC3339 时间转换到指定时区显示，如 {{inZone "Asia/Shanghai" .Deadline}}
+func templateInZone(zone, value string) (string, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return "", fmt.Errorf("invalid timezone %q: %w", zone, err)
+	}
+	return formatReportTime(value, loc), nil
+}
+
+// templateDuration 按当前语言显示持续时间，接受秒数（如 .TimeDifferenceSeconds，负数按绝对值）或 time.Duration
+func templateDuration(value any) (string, error) {
+	var d time.Duration
+	switch v := value.(type) {
+	case time.Duration:
+		d = v
+	case int:
+		d = time.Duration(v) * time.Second
+	case int64:
+		d = time.Duration(v) * time.Second
+	case *int64:
+		if v == nil {
+			return "", nil
+		}
+		d = time.Duration(*v) * time.Second
+	case float64:
+		d = time.Duration(v * float64(time.Second))
+	default:
+		return "", fmt.Errorf("duration: unsupported value %v", value)
+	}
+	if d < 0 {
+		d = -d
+	}
+	return i18n.FormatDuration(d), nil
+}
+
+// maskText 隐藏文字的中间部分（用于姓名、账号），如 "张三丰" -> "张*丰"、"alice" -> "a***e"
+func maskText(s string) string {
+	runes := []rune(s)
+	switch len(runes) {
+	case 0:
+		return ""
+	case 1:
+		return "*"
+	case 2:
+		return string(runes[0]) + "*"
+	default:
+		return string(runes[0]) + strings.Repeat("*", len(runes)-2) + string(runes[len(runes)-1])
+	}
+}
+
+// verdictStatus 结论值，没有结论时为 unknown
+func verdictStatus(v *models.Verdict) string {
+	if v == nil || v.Status == "" {
+		return models.VerdictUnknown
+	}
+	return v.Status
+}
+
+// entryVerdict 汇总条目的结论值
+func entryVerdict(result *models.AnalysisResult) string {
+	if result == nil {
+		return models.VerdictUnknown
+	}
+	return verdictStatus(result.Verdict)
+}
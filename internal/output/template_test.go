@@ -0,0 +1,102 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestTemplateFormatter_Builtin(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := NewTemplateFormatter("oneline", time.UTC)
+	if err != nil {
+		t.Fatalf("NewTemplateFormatter failed: %v", err)
+	}
+	result := lateResult()
+	result.TimeDifference = "1 hours after deadline"
+	if err := f.Format(&buf, result); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	want := "🔴 alice/demo Late | 2025-09-30 17:00:00 UTC | 1 hours after deadline\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	// 没有 summary 子模板时每个仓库输出一行
+	buf.Reset()
+	entries := []SummaryEntry{{Result: result}, {Result: result}}
+	if err := f.FormatSummary(&buf, entries); err != nil {
+		t.Fatalf("FormatSummary failed: %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 2 {
+		t.Errorf("Expected one line per repository, got %q", buf.String())
+	}
+}
+
+func TestTemplateFormatter_WeChatSummary(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := NewTemplateFormatter("wechat", time.UTC)
+	if err != nil {
+		t.Fatalf("NewTemplateFormatter failed: %v", err)
+	}
+	entries := []SummaryEntry{
+		{Row: 2, Name: "张三丰", Team: "红队", Result: lateResult()},
+		{Row: 3, Name: "Bob", Result: &models.AnalysisResult{Verdict: &models.Verdict{Status: models.VerdictOnTime}}},
+	}
+	if err := f.FormatSummary(&buf, entries); err != nil {
+		t.Fatalf("FormatSummary failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"2 repositories: 1 on time, 1 late, 0 other", "2. 红队 张*丰：🔴 Late", "3. B*b：🟢 On time"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	// check 的汇总条目没有行号和姓名，按仓库显示
+	buf.Reset()
+	entries = []SummaryEntry{{Repository: "alice/demo", Platform: models.PlatformGitHub, Result: lateResult()}}
+	if err := f.FormatSummary(&buf, entries); err != nil {
+		t.Fatalf("FormatSummary failed: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "\nalice/demo：🔴 Late") || strings.Contains(out, "0. ") {
+		t.Errorf("Expected the repository without a row number, got:\n%s", out)
+	}
+}
+
+func TestTemplateFormatter_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notice.tmpl")
+	text := `{{repo .}} {{formatTime "01-02 15:04" .LastCodeEvent.CreatedAt}} {{inZone "Asia/Shanghai" .Deadline}} {{duration .TimeDifferenceSeconds}} {{mask .EarliestCodeEvent.ActorLogin}}`
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	f, err := NewTemplateFormatter(path, time.UTC)
+	if err != nil {
+		t.Fatalf("NewTemplateFormatter failed: %v", err)
+	}
+	result := lateResult()
+	seconds := int64(3660)
+	result.TimeDifferenceSeconds = &seconds
+	if err := f.Format(&buf, result); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	want := "alice/demo 09-30 17:00 2025-09-30 23:59:00 CST 1 hours 1 minutes a***e"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	if _, err := NewTemplateFormatter(filepath.Join(t.TempDir(), "missing.tmpl"), nil); err == nil {
+		t.Error("Expected error for missing template file")
+	}
+	if _, err := NewTemplateFormatter("", nil); err == nil {
+		t.Error("Expected error without template")
+	}
+}
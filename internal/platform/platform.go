@@ -0,0 +1,74 @@
+package platform
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/api"
+	"github.com/luoliwoshang/git-event-monitor/internal/api/gitee"
+	"github.com/luoliwoshang/git-event-monitor/internal/api/github"
+	"github.com/luoliwoshang/git-event-monitor/internal/api/ratelimit"
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// Supported 支持的平台列表
+var Supported = []models.Platform{models.PlatformGitHub, models.PlatformGitee}
+
+// Parse 解析平台名称（不区分大小写）
+func Parse(name string) (models.Platform, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "github":
+		return models.PlatformGitHub, nil
+	case "gitee":
+		return models.PlatformGitee, nil
+	default:
+		return "", fmt.Errorf("unsupported platform: %s (supported: github, gitee)", name)
+	}
+}
+
+// FromHost 根据主机名识别平台，无法识别时返回空字符串
+func FromHost(host string) models.Platform {
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	switch host {
+	case "github.com":
+		return models.PlatformGitHub
+	case "gitee.com":
+		return models.PlatformGitee
+	default:
+		return ""
+	}
+}
+
+// NewClient 创建对应平台的 API 客户端
+func NewClient(p models.Platform) (api.Client, error) {
+	switch p {
+	case models.PlatformGitHub:
+		return github.NewClient(), nil
+	case models.PlatformGitee:
+		return gitee.NewClient(), nil
+	default:
+		return nil, fmt.Errorf("unsupported platform: %s (supported: github, gitee)", p)
+	}
+}
+
+// NewClientWithHTTPClient 使用自定义 HTTP 客户端创建对应平台的 API 客户端
+func NewClientWithHTTPClient(p models.Platform, httpClient *http.Client) (api.Client, error) {
+	switch p {
+	case models.PlatformGitHub:
+		return github.NewClientWithHTTPClient(httpClient), nil
+	case models.PlatformGitee:
+		return gitee.NewClientWithHTTPClient(httpClient), nil
+	default:
+		return nil, fmt.Errorf("unsupported platform: %s (supported: github, gitee)", p)
+	}
+}
+
+// NewRateLimitedClient 创建带共享配额限制的 API 客户端
+// 同一个 limiter 的所有请求共同遵守平台返回的配额，timeout 为获取配额后单次请求的超时时间
+func NewRateLimitedClient(p models.Platform, limiter *ratelimit.Limiter, timeout time.Duration) (api.Client, error) {
+	return NewClientWithHTTPClient(p, &http.Client{
+		Transport: ratelimit.NewTransport(nil, limiter, timeout),
+	})
+}
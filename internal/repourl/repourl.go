@@ -0,0 +1,200 @@
+// Package repourl 把报名表中各种写法的仓库地址规范化为 平台 + owner/repo
+package repourl
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// 无法解析的原因，可以用 errors.Is 判断
+var (
+	ErrEmpty            = errors.New("empty repository URL")
+	ErrMultipleURLs     = errors.New("contains more than one URL")
+	ErrInvalidURL       = errors.New("malformed URL")
+	ErrUnsupportedHost  = errors.New("unsupported host")
+	ErrMissingRepo      = errors.New("URL does not point to a repository")
+	ErrInvalidName      = errors.New("invalid owner or repository name")
+	ErrPlatformMismatch = errors.New("platform does not match URL")
+)
+
+// hosts 支持的主机名
+var hosts = map[string]models.Platform{
+	"github.com": models.PlatformGitHub,
+	"gitee.com":  models.PlatformGitee,
+}
+
+// reservedOwners 平台保留的一级路径，不是用户或组织名
+var reservedOwners = map[models.Platform]map[string]bool{
+	models.PlatformGitHub: {
+		"orgs": true, "settings": true, "topics": true, "explore": true, "marketplace": true,
+		"sponsors": true, "notifications": true, "login": true, "new": true, "search": true,
+	},
+	models.PlatformGitee: {
+		"organizations": true, "explore": true, "login": true, "search": true, "projects": true,
+	},
+}
+
+// validNamePattern owner 和仓库名只允许字母数字、连字符、下划线、点号
+var validNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// trimChars 地址首尾需要去掉的标点（括号、引号以及中英文句读）
+const trimChars = ".,;:!?'\"()[]<>{}`" + "。，；：！？、（）【】《》「」『』“”‘’…"
+
+// Repository 规范化后的仓库
+type Repository struct {
+	Platform models.Platform
+	Owner    string
+	Name     string
+}
+
+// FullName 返回 owner/repo
+func (r Repository) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
+// URL 返回仓库的规范 HTTPS 地址
+func (r Repository) URL() string {
+	for host, p := range hosts {
+		if p == r.Platform {
+			return "https://" + host + "/" + r.FullName()
+		}
+	}
+	return ""
+}
+
+// Parse 解析仓库地址
+// 支持 HTTPS/HTTP、SSH（git@host:owner/repo 和 ssh://git@host/owner/repo）、git:// 以及不带协议的写法，
+// 忽略 www. 前缀、端口、/tree/...、/blob/... 等深层路径、查询参数和锚点，
+// 并兼容全角字符和首尾的中英文标点；无法解析时返回说明原因的错误
+func Parse(raw string) (Repository, error) {
+	s := normalize(raw)
+	if s == "" {
+		return Repository{}, ErrEmpty
+	}
+	if strings.IndexFunc(s, unicode.IsSpace) != -1 || strings.Count(strings.ToLower(s), "://") > 1 {
+		return Repository{}, fmt.Errorf("%w: %q", ErrMultipleURLs, raw)
+	}
+
+	host, path, err := split(s)
+	if err != nil {
+		return Repository{}, fmt.Errorf("%w: %q", err, raw)
+	}
+
+	p, ok := hosts[host]
+	if !ok {
+		return Repository{}, fmt.Errorf("%w: %s", ErrUnsupportedHost, host)
+	}
+
+	return parsePath(p, path, raw)
+}
+
+// ParseFor 按指定平台解析仓库地址
+// 除了 Parse 支持的写法，还接受不带域名的 owner/repo；地址中的平台与 p 不一致时返回 ErrPlatformMismatch
+func ParseFor(raw string, p models.Platform) (Repository, error) {
+	repo, err := Parse(raw)
+	if err == nil {
+		if repo.Platform != p {
+			return Repository{}, fmt.Errorf("%w: expected %s, got %s", ErrPlatformMismatch, p, repo.Platform)
+		}
+		return repo, nil
+	}
+	if !errors.Is(err, ErrUnsupportedHost) {
+		return Repository{}, err
+	}
+
+	// 第一段不是支持的域名时，按 owner/repo 解析
+	s := normalize(raw)
+	if strings.Contains(s, "://") || strings.Contains(s, "@") || strings.Count(strings.Trim(s, "/"), "/") != 1 {
+		return Repository{}, err
+	}
+	return parsePath(p, s, raw)
+}
+
+// normalize 全角字符转半角，去掉首尾空白和标点
+func normalize(raw string) string {
+	s := strings.Map(func(r rune) rune {
+		switch {
+		case r == '　':
+			return ' '
+		case r >= '！' && r <= '～':
+			// 全角 ASCII 字符
+			return r - 0xFEE0
+		case r == '\u200b' || r == '\ufeff':
+			// 零宽字符，常见于从网页复制的内容
+			return -1
+		default:
+			return r
+		}
+	}, raw)
+	return strings.Trim(strings.TrimSpace(s), trimChars+" \t\r\n")
+}
+
+// split 拆分主机名和路径，主机名转为小写并去掉 www. 前缀和端口
+func split(s string) (host, path string, err error) {
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasPrefix(lower, "git@"):
+		// scp 风格：git@host:owner/repo.git
+		rest := s[len("git@"):]
+		i := strings.Index(rest, ":")
+		if i == -1 {
+			return "", "", ErrInvalidURL
+		}
+		host, path = rest[:i], rest[i+1:]
+	case strings.Contains(lower, "://"):
+		if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") &&
+			!strings.HasPrefix(lower, "ssh://") && !strings.HasPrefix(lower, "git://") {
+			return "", "", ErrInvalidURL
+		}
+		u, parseErr := url.Parse(s)
+		if parseErr != nil {
+			return "", "", ErrInvalidURL
+		}
+		host, path = u.Hostname(), u.Path
+	default:
+		// 不带协议：github.com/owner/repo
+		u, parseErr := url.Parse("https://" + s)
+		if parseErr != nil {
+			return "", "", ErrInvalidURL
+		}
+		host, path = u.Hostname(), u.Path
+	}
+
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	if host == "" {
+		return "", "", ErrInvalidURL
+	}
+	return host, path, nil
+}
+
+// parsePath 从路径中取出 owner 和仓库名，忽略后面的深层路径、查询参数和锚点
+func parsePath(p models.Platform, path, raw string) (Repository, error) {
+	if i := strings.IndexAny(path, "?#"); i != -1 {
+		path = path[:i]
+	}
+
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) < 2 || reservedOwners[p][strings.ToLower(parts[0])] {
+		return Repository{}, fmt.Errorf("%w: %q", ErrMissingRepo, raw)
+	}
+
+	owner := parts[0]
+	name := strings.TrimSuffix(parts[1], ".git")
+	if !validNamePattern.MatchString(owner) || !validNamePattern.MatchString(name) ||
+		strings.Trim(name, ".") == "" {
+		return Repository{}, fmt.Errorf("%w: %q", ErrInvalidName, owner+"/"+name)
+	}
+
+	return Repository{Platform: p, Owner: owner, Name: name}, nil
+}
@@ -0,0 +1,144 @@
+package repourl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	github := func(owner, name string) Repository {
+		return Repository{Platform: models.PlatformGitHub, Owner: owner, Name: name}
+	}
+	gitee := func(owner, name string) Repository {
+		return Repository{Platform: models.PlatformGitee, Owner: owner, Name: name}
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  Repository
+	}{
+		{"https", "https://github.com/owner/repo", github("owner", "repo")},
+		{"http", "http://github.com/owner/repo", github("owner", "repo")},
+		{"dot git", "https://github.com/owner/repo.git", github("owner", "repo")},
+		{"trailing slash", "https://github.com/owner/repo/", github("owner", "repo")},
+		{"no scheme", "github.com/owner/repo", github("owner", "repo")},
+		{"www", "https://www.github.com/owner/repo", github("owner", "repo")},
+		{"upper case host", "HTTPS://GitHub.com/Owner/Repo", github("Owner", "Repo")},
+		{"tree", "https://github.com/owner/repo/tree/main", github("owner", "repo")},
+		{"tree nested", "https://github.com/owner/repo/tree/feature/x/src", github("owner", "repo")},
+		{"blob", "https://github.com/owner/repo/blob/main/README.md", github("owner", "repo")},
+		{"query", "https://github.com/owner/repo?tab=readme-ov-file", github("owner", "repo")},
+		{"fragment", "https://github.com/owner/repo#readme", github("owner", "repo")},
+		{"query and fragment", "https://github.com/owner/repo/?tab=readme#readme", github("owner", "repo")},
+		{"scp ssh", "git@github.com:owner/repo.git", github("owner", "repo")},
+		{"ssh scheme", "ssh://git@github.com/owner/repo.git", github("owner", "repo")},
+		{"ssh scheme with port", "ssh://git@github.com:22/owner/repo.git", github("owner", "repo")},
+		{"git scheme", "git://github.com/owner/repo.git", github("owner", "repo")},
+		{"surrounding spaces", "  https://github.com/owner/repo \n", github("owner", "repo")},
+		{"trailing chinese period", "https://github.com/owner/repo。", github("owner", "repo")},
+		{"trailing chinese comma", "https://github.com/owner/repo，", github("owner", "repo")},
+		{"trailing english period", "https://github.com/owner/repo.", github("owner", "repo")},
+		{"brackets", "（https://github.com/owner/repo）", github("owner", "repo")},
+		{"angle brackets", "<https://github.com/owner/repo>", github("owner", "repo")},
+		{"full-width slash and colon", "https：／／github.com／owner／repo", github("owner", "repo")},
+		{"full-width letters", "ｈｔｔｐｓ://ｇｉｔｈｕｂ.com/owner/repo", github("owner", "repo")},
+		{"zero width space", "https://github.com/owner/repo\u200b", github("owner", "repo")},
+		{"dots and dashes", "https://github.com/my-org/my.repo_name", github("my-org", "my.repo_name")},
+		{"long deep link", "https://github.com/owner/repo/blob/main/" + strings.Repeat("dir/", 60) + "file.go", github("owner", "repo")},
+		{"gitee https", "https://gitee.com/owner/repo", gitee("owner", "repo")},
+		{"gitee tree", "https://gitee.com/owner/repo/tree/master/", gitee("owner", "repo")},
+		{"gitee ssh", "git@gitee.com:owner/repo.git", gitee("owner", "repo")},
+		{"gitee no scheme", "gitee.com/owner/repo.git", gitee("owner", "repo")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{"empty", "", ErrEmpty},
+		{"only punctuation", " 。", ErrEmpty},
+		{"two urls", "https://github.com/a/b https://github.com/c/d", ErrMultipleURLs},
+		{"two urls without space", "https://github.com/a/bhttps://github.com/c/d", ErrMultipleURLs},
+		{"unsupported host", "https://gitlab.com/owner/repo", ErrUnsupportedHost},
+		{"plain text", "暂无", ErrUnsupportedHost},
+		{"unsupported scheme", "ftp://github.com/owner/repo", ErrInvalidURL},
+		{"scp without colon", "git@github.com/owner/repo", ErrInvalidURL},
+		{"owner only", "https://github.com/owner", ErrMissingRepo},
+		{"host only", "https://github.com", ErrMissingRepo},
+		{"reserved path", "https://github.com/orgs/owner/repositories", ErrMissingRepo},
+		{"invalid characters", "https://github.com/owner/仓库", ErrInvalidName},
+		{"only dot git", "https://github.com/owner/.git", ErrInvalidName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.input, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Repository
+		wantErr error
+	}{
+		{"short form", "owner/repo", Repository{models.PlatformGitee, "owner", "repo"}, nil},
+		{"short form with dot git", "owner/repo.git", Repository{models.PlatformGitee, "owner", "repo"}, nil},
+		{"full url", "https://gitee.com/owner/repo/tree/master", Repository{models.PlatformGitee, "owner", "repo"}, nil},
+		{"mismatch", "https://github.com/owner/repo", Repository{}, ErrPlatformMismatch},
+		{"unsupported host", "https://gitlab.com/owner/repo", Repository{}, ErrUnsupportedHost},
+		{"too many segments", "owner/repo/extra", Repository{}, ErrUnsupportedHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFor(tt.input, models.PlatformGitee)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ParseFor(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFor(%q) failed: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFor(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepository_URL(t *testing.T) {
+	repo := Repository{Platform: models.PlatformGitee, Owner: "owner", Name: "repo"}
+	if got := repo.URL(); got != "https://gitee.com/owner/repo" {
+		t.Errorf("URL() = %s", got)
+	}
+	if got := repo.FullName(); got != "owner/repo" {
+		t.Errorf("FullName() = %s", got)
+	}
+}
@@ -0,0 +1,233 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// Gitee Webhook 请求头
+const (
+	giteeEventHeader     = "X-Gitee-Event"
+	giteeTokenHeader     = "X-Gitee-Token"
+	giteeTimestampHeader = "X-Gitee-Timestamp"
+)
+
+// giteeRepository Gitee Webhook 中的仓库字段
+type giteeRepository struct {
+	ID       int64  `json:"id"`
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// giteeUser Gitee Webhook 中的用户字段
+type giteeUser struct {
+	Login     string `json:"login"`
+	Username  string `json:"username"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// giteePushPayload Gitee Push Hook / Tag Push Hook 载荷
+type giteePushPayload struct {
+	Ref        string          `json:"ref"`
+	Before     string          `json:"before"`
+	After      string          `json:"after"`
+	Created    bool            `json:"created"`
+	Deleted    bool            `json:"deleted"`
+	Compare    string          `json:"compare"`
+	Commits    []webhookCommit `json:"commits"`
+	Repository giteeRepository `json:"repository"`
+	Sender     giteeUser       `json:"sender"`
+}
+
+// giteeMergeRequestPayload Gitee Merge Request Hook 载荷
+type giteeMergeRequestPayload struct {
+	Action      string `json:"action"`
+	State       string `json:"state"`
+	PullRequest struct {
+		Number         int    `json:"number"`
+		HTMLURL        string `json:"html_url"`
+		Merged         bool   `json:"merged"`
+		MergedAt       string `json:"merged_at"`
+		MergeCommitSHA string `json:"merge_commit_sha"`
+		Head           struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository giteeRepository `json:"repository"`
+	Sender     giteeUser       `json:"sender"`
+}
+
+// giteeTimestampWindow 签名模式下 X-Gitee-Timestamp 与服务器时间允许的最大偏差（Gitee 建议 5 分钟）
+const giteeTimestampWindow = 5 * time.Minute
+
+// Gitee 校验失败的原因
+var (
+	errGiteeInvalidToken = errors.New("invalid token or signature")
+	errGiteeStaleToken   = errors.New("timestamp outside the allowed window")
+	errGiteeReplayed     = errors.New("signature already used")
+)
+
+// verifyGiteeToken 校验 X-Gitee-Token，signed 表示使用了签名模式
+// Gitee 支持两种方式：直接携带 WebHook 密码，或携带签名
+// 签名算法：base64(HMAC-SHA256(secret, timestamp + "\n" + secret))，timestamp 为毫秒时间戳；
+// 签名不覆盖请求体，因此时间戳与 now 相差超过 giteeTimestampWindow 时拒绝，重放由 giteeReplayGuard 拦截
+func verifyGiteeToken(secret, token, timestamp string, now time.Time) (signed bool, err error) {
+	if secret == "" || token == "" {
+		return false, errGiteeInvalidToken
+	}
+
+	// 密码模式
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1 {
+		return false, nil
+	}
+
+	// 签名模式
+	if timestamp == "" {
+		return false, errGiteeInvalidToken
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	valid := hmac.Equal([]byte(token), []byte(expected))
+	// 部分版本会对签名做 URL 编码
+	if decoded, err := url.QueryUnescape(token); !valid && err == nil {
+		valid = hmac.Equal([]byte(decoded), []byte(expected))
+	}
+	if !valid {
+		return true, errGiteeInvalidToken
+	}
+
+	millis, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return true, errGiteeStaleToken
+	}
+	if skew := now.Sub(time.UnixMilli(millis)); skew > giteeTimestampWindow || skew < -giteeTimestampWindow {
+		return true, errGiteeStaleToken
+	}
+	return true, nil
+}
+
+// giteeReplayGuard 记录时间窗口内已使用的签名，同一签名只接受一次
+type giteeReplayGuard struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// accept 签名未使用过时记录并返回 true；超过两个时间窗口的记录会被清理
+func (g *giteeReplayGuard) accept(signature string, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.seen == nil {
+		g.seen = make(map[string]time.Time)
+	}
+	for key, seenAt := range g.seen {
+		if now.Sub(seenAt) > 2*giteeTimestampWindow {
+			delete(g.seen, key)
+		}
+	}
+	if _, ok := g.seen[signature]; ok {
+		return false
+	}
+	g.seen[signature] = now
+	return true
+}
+
+// parseGiteeWebhook 将 Gitee Webhook 载荷转换为统一事件
+// 返回 nil 事件表示该 Webhook 类型无需记录
+func parseGiteeWebhook(hookEvent string, body []byte, receivedAt time.Time) (*models.UnifiedEvent, error) {
+	switch hookEvent {
+	case "Push Hook", "Tag Push Hook":
+		var payload giteePushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("decode push payload: %w", err)
+		}
+		if payload.Deleted {
+			return nil, nil
+		}
+		event := newPushEvent(payload.Ref, payload.Before, payload.After, payload.Commits, receivedAt)
+		event.Payload["compare"] = payload.Compare
+		fillGiteeSource(event, payload.Repository, payload.Sender)
+		return event, nil
+
+	case "Merge Request Hook":
+		var payload giteeMergeRequestPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("decode merge request payload: %w", err)
+		}
+		// 只记录已合并的 Merge Request
+		if payload.Action != "merge" && !(payload.PullRequest.Merged && payload.State == "merged") {
+			return nil, nil
+		}
+		event := newMergeEvent(payload.Action, payload.PullRequest.Number, payload.PullRequest.HTMLURL,
+			payload.PullRequest.Head.Ref, payload.PullRequest.Base.Ref,
+			payload.PullRequest.MergeCommitSHA, payload.PullRequest.MergedAt, receivedAt)
+		fillGiteeSource(event, payload.Repository, payload.Sender)
+		return event, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// fillGiteeSource 填充统一事件的仓库和触发者信息
+func fillGiteeSource(event *models.UnifiedEvent, repo giteeRepository, sender giteeUser) {
+	event.ActorLogin = sender.Login
+	if event.ActorLogin == "" {
+		event.ActorLogin = sender.Username
+	}
+	event.ActorAvatarURL = sender.AvatarURL
+	event.RepoName = repo.FullName
+	event.RepoURL = repo.HTMLURL
+}
+
+// handleGitee 处理 Gitee Webhook 请求
+func (s *Server) handleGitee(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	receivedAt := s.now()
+	token := r.Header.Get(giteeTokenHeader)
+	signed, err := verifyGiteeToken(s.config.GiteeSecret, token, r.Header.Get(giteeTimestampHeader), receivedAt)
+	if err == nil && signed && !s.giteeSignatures.accept(token, receivedAt) {
+		err = errGiteeReplayed
+	}
+	if err != nil {
+		s.logf("❌ Gitee webhook rejected: %v", err)
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	hookEvent := r.Header.Get(giteeEventHeader)
+	event, err := parseGiteeWebhook(hookEvent, body, receivedAt)
+	if err != nil {
+		s.logf("❌ Gitee webhook %s: %v", hookEvent, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.save(w, &Record{
+		ReceivedAt: receivedAt,
+		Platform:   models.PlatformGitee,
+		HookEvent:  hookEvent,
+		Event:      event,
+	})
+}
@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// GitHub Webhook 请求头
+const (
+	githubEventHeader     = "X-GitHub-Event"
+	githubDeliveryHeader  = "X-GitHub-Delivery"
+	githubSignatureHeader = "X-Hub-Signature-256"
+)
+
+// githubRepository GitHub Webhook 中的仓库字段
+type githubRepository struct {
+	ID       int64  `json:"id"`
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// githubSender GitHub Webhook 中的触发者字段
+type githubSender struct {
+	Login     string `json:"login"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// githubPushPayload GitHub push Webhook 载荷
+type githubPushPayload struct {
+	Ref        string           `json:"ref"`
+	Before     string           `json:"before"`
+	After      string           `json:"after"`
+	Created    bool             `json:"created"`
+	Deleted    bool             `json:"deleted"`
+	Forced     bool             `json:"forced"`
+	Compare    string           `json:"compare"`
+	Commits    []webhookCommit  `json:"commits"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubSender     `json:"sender"`
+}
+
+// githubPullRequestPayload GitHub pull_request Webhook 载荷
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		HTMLURL        string `json:"html_url"`
+		Merged         bool   `json:"merged"`
+		MergedAt       string `json:"merged_at"`
+		MergeCommitSHA string `json:"merge_commit_sha"`
+		Head           struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubSender     `json:"sender"`
+}
+
+// verifyGitHubSignature 校验 X-Hub-Signature-256（HMAC-SHA256，十六进制，带 sha256= 前缀）
+func verifyGitHubSignature(secret string, body []byte, signature string) bool {
+	const prefix = "sha256="
+	if secret == "" || !strings.HasPrefix(signature, prefix) {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// parseGitHubWebhook 将 GitHub Webhook 载荷转换为统一事件
+// 返回 nil 事件表示该 Webhook 类型无需记录
+func parseGitHubWebhook(hookEvent string, body []byte, receivedAt time.Time) (*models.UnifiedEvent, error) {
+	switch hookEvent {
+	case "push":
+		var payload githubPushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("decode push payload: %w", err)
+		}
+		if payload.Deleted {
+			return nil, nil
+		}
+		event := newPushEvent(payload.Ref, payload.Before, payload.After, payload.Commits, receivedAt)
+		event.Payload["forced"] = payload.Forced
+		event.Payload["compare"] = payload.Compare
+		fillGitHubSource(event, payload.Repository, payload.Sender)
+		return event, nil
+
+	case "pull_request":
+		var payload githubPullRequestPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("decode pull_request payload: %w", err)
+		}
+		// 只记录已合并的 Pull Request，合并才会把代码带入目标分支
+		if payload.Action != "closed" || !payload.PullRequest.Merged {
+			return nil, nil
+		}
+		event := newMergeEvent(payload.Action, payload.Number, payload.PullRequest.HTMLURL,
+			payload.PullRequest.Head.Ref, payload.PullRequest.Base.Ref,
+			payload.PullRequest.MergeCommitSHA, payload.PullRequest.MergedAt, receivedAt)
+		fillGitHubSource(event, payload.Repository, payload.Sender)
+		return event, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// fillGitHubSource 填充统一事件的仓库和触发者信息
+func fillGitHubSource(event *models.UnifiedEvent, repo githubRepository, sender githubSender) {
+	event.ActorLogin = sender.Login
+	event.ActorAvatarURL = sender.AvatarURL
+	event.RepoName = repo.FullName
+	event.RepoURL = repo.HTMLURL
+}
+
+// handleGitHub 处理 GitHub Webhook 请求
+func (s *Server) handleGitHub(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	if !verifyGitHubSignature(s.config.GitHubSecret, body, r.Header.Get(githubSignatureHeader)) {
+		s.logf("❌ GitHub webhook rejected: invalid signature")
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	hookEvent := r.Header.Get(githubEventHeader)
+	if hookEvent == "ping" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	receivedAt := s.now()
+	event, err := parseGitHubWebhook(hookEvent, body, receivedAt)
+	if err != nil {
+		s.logf("❌ GitHub webhook %s: %v", hookEvent, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.save(w, &Record{
+		ReceivedAt: receivedAt,
+		Platform:   models.PlatformGitHub,
+		DeliveryID: r.Header.Get(githubDeliveryHeader),
+		HookEvent:  hookEvent,
+		Event:      event,
+	})
+}
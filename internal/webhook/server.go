@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// maxBodySize Webhook 请求体大小上限
+const maxBodySize = 25 << 20
+
+// Config Webhook 服务配置
+type Config struct {
+	// GitHubSecret GitHub Webhook 的 Secret，为空时不注册 GitHub 端点
+	GitHubSecret string
+	// GiteeSecret Gitee WebHook 的密码或签名密钥，为空时不注册 Gitee 端点
+	GiteeSecret string
+	// Log 日志输出，为空时不输出日志
+	Log io.Writer
+}
+
+// Server Webhook 接收服务
+// 校验平台签名后，把 push、合并请求和标签推送解析为统一事件并带上服务器接收时间存储
+type Server struct {
+	config  Config
+	records Store
+	now     func() time.Time
+	mux     *http.ServeMux
+	// giteeSignatures 已使用的 Gitee 签名，拒绝重放
+	giteeSignatures giteeReplayGuard
+}
+
+// NewServer 创建 Webhook 接收服务
+func NewServer(config Config, store Store) *Server {
+	s := &Server{
+		config:  config,
+		records: store,
+		now:     time.Now,
+		mux:     http.NewServeMux(),
+	}
+
+	if config.GitHubSecret != "" {
+		s.mux.HandleFunc("POST /webhooks/github", s.handleGitHub)
+	}
+	if config.GiteeSecret != "" {
+		s.mux.HandleFunc("POST /webhooks/gitee", s.handleGitee)
+	}
+	s.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return s
+}
+
+// ServeHTTP 实现 http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// readBody 读取请求体，超过上限时返回 413
+func (s *Server) readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return nil, false
+	}
+	return body, true
+}
+
+// save 保存记录并写回响应，无需记录的 Webhook 返回 202
+func (s *Server) save(w http.ResponseWriter, record *Record) {
+	if record.Event == nil {
+		s.logf("ℹ️  %s webhook %q ignored", record.Platform, record.HookEvent)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if record.Event.ID == "" {
+		record.Event.ID = record.DeliveryID
+	}
+	if record.Event.ID == "" {
+		record.Event.ID = fmt.Sprintf("%s-%d", record.Platform, record.ReceivedAt.UnixNano())
+	}
+
+	if err := s.records.Append(record); err != nil {
+		s.logf("❌ Failed to store %s webhook: %v", record.Platform, err)
+		http.Error(w, "failed to store event", http.StatusInternalServerError)
+		return
+	}
+
+	s.logf("✅ %s %s %s (%s)", record.Platform, record.Event.Type, record.Event.RepoName, record.Event.CreatedAt)
+	w.WriteHeader(http.StatusOK)
+}
+
+// logf 输出一行日志
+func (s *Server) logf(format string, args ...interface{}) {
+	if s.config.Log == nil {
+		return
+	}
+	fmt.Fprintf(s.config.Log, "[%s] %s\n", s.now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
+
+// webhookCommit Webhook 载荷中的提交信息（GitHub 和 Gitee 结构一致）
+type webhookCommit struct {
+	ID        string `json:"id"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+	URL       string `json:"url"`
+	Author    struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+}
+
+// newPushEvent 根据推送信息构造统一事件
+// 分支推送对应 PushEvent，标签推送对应 CreateEvent（与平台 events API 的类型保持一致）
+// CreatedAt 使用服务器接收时间，而不是提交中可被修改的时间
+func newPushEvent(ref, before, after string, commits []webhookCommit, receivedAt time.Time) *models.UnifiedEvent {
+	event := &models.UnifiedEvent{
+		BaseEvent: models.BaseEvent{
+			CreatedAt: receivedAt.UTC().Format(time.RFC3339),
+		},
+	}
+
+	if tag, ok := strings.CutPrefix(ref, "refs/tags/"); ok {
+		event.Type = "CreateEvent"
+		event.Payload = map[string]interface{}{
+			"ref":      tag,
+			"ref_type": "tag",
+			"head":     after,
+		}
+		return event
+	}
+
+	payloadCommits := make([]interface{}, 0, len(commits))
+	for _, commit := range commits {
+		payloadCommits = append(payloadCommits, map[string]interface{}{
+			"sha":     commit.ID,
+			"message": commit.Message,
+			"url":     commit.URL,
+			"author": map[string]interface{}{
+				"name":  commit.Author.Name,
+				"email": commit.Author.Email,
+			},
+		})
+	}
+
+	event.Type = "PushEvent"
+	event.Payload = map[string]interface{}{
+		"ref":     ref,
+		"before":  before,
+		"head":    after,
+		"size":    len(commits),
+		"commits": payloadCommits,
+	}
+	return event
+}
+
+// newMergeEvent 根据已合并的 Pull Request / Merge Request 构造统一事件
+func newMergeEvent(action string, number int, htmlURL, headRef, baseRef, mergeSHA, mergedAt string, receivedAt time.Time) *models.UnifiedEvent {
+	return &models.UnifiedEvent{
+		BaseEvent: models.BaseEvent{
+			Type:      "PullRequestEvent",
+			CreatedAt: receivedAt.UTC().Format(time.RFC3339),
+		},
+		Payload: map[string]interface{}{
+			"action": action,
+			"number": number,
+			"pull_request": map[string]interface{}{
+				"html_url":         htmlURL,
+				"merged":           true,
+				"merged_at":        mergedAt,
+				"merge_commit_sha": mergeSHA,
+				"head":             map[string]interface{}{"ref": headRef},
+				"base":             map[string]interface{}{"ref": baseRef},
+			},
+		},
+	}
+}
@@ -0,0 +1,268 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// memoryStore 测试用内存存储
+type memoryStore struct {
+	records []*Record
+}
+
+func (m *memoryStore) Append(record *Record) error {
+	m.records = append(m.records, record)
+	return nil
+}
+
+func newTestServer(store Store) *Server {
+	s := NewServer(Config{GitHubSecret: "gh-secret", GiteeSecret: "gitee-secret"}, store)
+	s.now = func() time.Time { return time.Date(2025, 9, 30, 15, 59, 0, 0, time.UTC) }
+	return s
+}
+
+func githubSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// giteeSignedRequest 构造携带签名的 Gitee Tag Push 请求
+func giteeSignedRequest(secret, timestamp string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	sign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	body := []byte(`{
+		"ref": "refs/tags/v1.0",
+		"after": "ccc",
+		"repository": {"full_name": "team/project", "html_url": "https://gitee.com/team/project"},
+		"sender": {"username": "bob"}
+	}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/gitee", bytes.NewReader(body))
+	req.Header.Set(giteeEventHeader, "Tag Push Hook")
+	req.Header.Set(giteeTokenHeader, sign)
+	req.Header.Set(giteeTimestampHeader, timestamp)
+	return req
+}
+
+func TestServer_GitHubPush(t *testing.T) {
+	store := &memoryStore{}
+	server := newTestServer(store)
+
+	body := []byte(`{
+		"ref": "refs/heads/main",
+		"before": "aaa",
+		"after": "bbb",
+		"commits": [{"id": "bbb", "message": "final", "author": {"name": "alice", "email": "a@example.com"}}],
+		"repository": {"full_name": "team/project", "html_url": "https://github.com/team/project"},
+		"sender": {"login": "alice"}
+	}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set(githubEventHeader, "push")
+	req.Header.Set(githubDeliveryHeader, "delivery-1")
+	req.Header.Set(githubSignatureHeader, githubSignature("gh-secret", body))
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if len(store.records) != 1 {
+		t.Fatalf("Expected 1 stored record, got %d", len(store.records))
+	}
+
+	event := store.records[0].Event
+	if event.Type != "PushEvent" {
+		t.Errorf("Expected PushEvent, got %s", event.Type)
+	}
+	if event.ID != "delivery-1" {
+		t.Errorf("Expected event ID from delivery header, got %s", event.ID)
+	}
+	if event.CreatedAt != "2025-09-30T15:59:00Z" {
+		t.Errorf("Expected CreatedAt to be receipt time, got %s", event.CreatedAt)
+	}
+	if event.RepoName != "team/project" || event.ActorLogin != "alice" {
+		t.Errorf("Unexpected repo/actor: %s/%s", event.RepoName, event.ActorLogin)
+	}
+	if event.Payload["ref"] != "refs/heads/main" {
+		t.Errorf("Expected ref refs/heads/main, got %v", event.Payload["ref"])
+	}
+}
+
+func TestServer_GitHubInvalidSignature(t *testing.T) {
+	store := &memoryStore{}
+	server := newTestServer(store)
+
+	body := []byte(`{"ref": "refs/heads/main"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set(githubEventHeader, "push")
+	req.Header.Set(githubSignatureHeader, githubSignature("wrong-secret", body))
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401, got %d", rec.Code)
+	}
+	if len(store.records) != 0 {
+		t.Fatalf("Expected no stored records, got %d", len(store.records))
+	}
+}
+
+func TestServer_GitHubUnmergedPullRequestIgnored(t *testing.T) {
+	store := &memoryStore{}
+	server := newTestServer(store)
+
+	body := []byte(`{"action": "closed", "number": 3, "pull_request": {"merged": false}}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set(githubEventHeader, "pull_request")
+	req.Header.Set(githubSignatureHeader, githubSignature("gh-secret", body))
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected status 202, got %d", rec.Code)
+	}
+	if len(store.records) != 0 {
+		t.Fatalf("Expected no stored records, got %d", len(store.records))
+	}
+}
+
+func TestServer_GiteeTagPushWithSignature(t *testing.T) {
+	store := &memoryStore{}
+	server := newTestServer(store)
+
+	// 2025-09-30 15:58:00 UTC，在服务器时间前 1 分钟
+	req := giteeSignedRequest("gitee-secret", "1759247880000")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if len(store.records) != 1 {
+		t.Fatalf("Expected 1 stored record, got %d", len(store.records))
+	}
+
+	event := store.records[0].Event
+	if event.Type != "CreateEvent" || event.Payload["ref"] != "v1.0" {
+		t.Errorf("Expected tag CreateEvent for v1.0, got %s %v", event.Type, event.Payload["ref"])
+	}
+	if event.ActorLogin != "bob" {
+		t.Errorf("Expected actor bob, got %s", event.ActorLogin)
+	}
+}
+
+func TestServer_GiteeStaleSignatureRejected(t *testing.T) {
+	store := &memoryStore{}
+	server := newTestServer(store)
+
+	// 2024-09-30 15:59:00 UTC，签名正确但时间戳已过期
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, giteeSignedRequest("gitee-secret", "1727711940000"))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401, got %d", rec.Code)
+	}
+	if len(store.records) != 0 {
+		t.Errorf("Expected no stored records, got %d", len(store.records))
+	}
+}
+
+func TestServer_GiteeReplayedSignatureRejected(t *testing.T) {
+	store := &memoryStore{}
+	server := newTestServer(store)
+
+	first := httptest.NewRecorder()
+	server.ServeHTTP(first, giteeSignedRequest("gitee-secret", "1759247880000"))
+	if first.Code != http.StatusOK {
+		t.Fatalf("Expected first delivery status 200, got %d", first.Code)
+	}
+
+	replay := httptest.NewRecorder()
+	server.ServeHTTP(replay, giteeSignedRequest("gitee-secret", "1759247880000"))
+	if replay.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected replayed delivery status 401, got %d", replay.Code)
+	}
+	if len(store.records) != 1 {
+		t.Errorf("Expected 1 stored record, got %d", len(store.records))
+	}
+}
+
+func TestServer_GiteeMergeRequestWithPassword(t *testing.T) {
+	store := &memoryStore{}
+	server := newTestServer(store)
+
+	body := []byte(`{
+		"action": "merge",
+		"state": "merged",
+		"pull_request": {"number": 7, "merged": true, "head": {"ref": "feature"}, "base": {"ref": "master"}},
+		"repository": {"full_name": "team/project"},
+		"sender": {"login": "carol"}
+	}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/gitee", bytes.NewReader(body))
+	req.Header.Set(giteeEventHeader, "Merge Request Hook")
+	req.Header.Set(giteeTokenHeader, "gitee-secret")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if len(store.records) != 1 || store.records[0].Event.Type != "PullRequestEvent" {
+		t.Fatalf("Expected a stored PullRequestEvent, got %+v", store.records)
+	}
+}
+
+func TestFileStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+
+	for i, repo := range []string{"team/project", "other/repo", "Team/Project"} {
+		err := store.Append(&Record{
+			ReceivedAt: time.Date(2025, 9, 30, 10, i, 0, 0, time.UTC),
+			Platform:   models.PlatformGitHub,
+			Event: &models.UnifiedEvent{
+				BaseEvent: models.BaseEvent{ID: repo, Type: "PushEvent"},
+				RepoName:  repo,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to append record: %v", err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Failed to close store: %v", err)
+	}
+
+	records, err := LoadRecords(path)
+	if err != nil {
+		t.Fatalf("Failed to load records: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+
+	events := EventsForRepo(records, models.PlatformGitHub, "team/project")
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events for team/project, got %d", len(events))
+	}
+	// 最新接收的事件排在最前
+	if events[0].ID != "Team/Project" {
+		t.Errorf("Expected newest event first, got %s", events[0].ID)
+	}
+}
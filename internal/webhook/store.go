@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/luoliwoshang/git-event-monitor/internal/models"
+)
+
+// Record 一条已接收的 Webhook 记录
+// ReceivedAt 为本服务收到请求时的服务器时间，与平台事件时间互为独立来源
+type Record struct {
+	ReceivedAt time.Time            `json:"received_at"`
+	Platform   models.Platform      `json:"platform"`
+	DeliveryID string               `json:"delivery_id,omitempty"`
+	HookEvent  string               `json:"hook_event"`
+	Event      *models.UnifiedEvent `json:"event"`
+}
+
+// Store Webhook 记录存储接口
+type Store interface {
+	Append(record *Record) error
+}
+
+// FileStore 以 JSONL 格式追加写入记录的文件存储
+type FileStore struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileStore 打开（或创建）JSONL 存储文件
+func NewFileStore(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+	return &FileStore{file: file}, nil
+}
+
+// Append 追加一条记录，每条记录占一行
+func (s *FileStore) Append(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭存储文件
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
+
+// LoadRecords 读取 JSONL 存储文件中的全部记录
+func LoadRecords(path string) ([]*Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+	defer file.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("decode record at line %d: %w", line, err)
+		}
+		records = append(records, &record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read store: %w", err)
+	}
+
+	return records, nil
+}
+
+// EventsForRepo 按接收时间倒序返回指定仓库的事件（与平台 events API 的顺序一致）
+func EventsForRepo(records []*Record, platform models.Platform, repo string) []*models.UnifiedEvent {
+	var events []*models.UnifiedEvent
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if record.Event == nil || record.Platform != platform {
+			continue
+		}
+		if !strings.EqualFold(record.Event.RepoName, repo) {
+			continue
+		}
+		events = append(events, record.Event)
+	}
+	return events
+}